@@ -9,6 +9,7 @@ package api
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -22,6 +23,9 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// Orders move PENDING -> PAID -> ACCEPTED -> PREPARING -> READY -> COMPLETED.
+// CANCELED and REFUNDED branch off that path; see order/status.go for the
+// exact transitions the order service allows.
 type OrderStatus int32
 
 const (
@@ -29,6 +33,11 @@ const (
 	OrderStatus_PENDING   OrderStatus = 1
 	OrderStatus_COMPLETED OrderStatus = 2
 	OrderStatus_CANCELED  OrderStatus = 3
+	OrderStatus_PAID      OrderStatus = 4
+	OrderStatus_ACCEPTED  OrderStatus = 5
+	OrderStatus_PREPARING OrderStatus = 6
+	OrderStatus_READY     OrderStatus = 7
+	OrderStatus_REFUNDED  OrderStatus = 8
 )
 
 // Enum value maps for OrderStatus.
@@ -38,12 +47,22 @@ var (
 		1: "PENDING",
 		2: "COMPLETED",
 		3: "CANCELED",
+		4: "PAID",
+		5: "ACCEPTED",
+		6: "PREPARING",
+		7: "READY",
+		8: "REFUNDED",
 	}
 	OrderStatus_value = map[string]int32{
 		"UNKNOWN":   0,
 		"PENDING":   1,
 		"COMPLETED": 2,
 		"CANCELED":  3,
+		"PAID":      4,
+		"ACCEPTED":  5,
+		"PREPARING": 6,
+		"READY":     7,
+		"REFUNDED":  8,
 	}
 )
 
@@ -74,19 +93,248 @@ func (OrderStatus) EnumDescriptor() ([]byte, []int) {
 	return file_api_oms_proto_rawDescGZIP(), []int{0}
 }
 
-type Order struct {
+type StockItemOrder int32
+
+const (
+	StockItemOrder_STOCK_ORDER_ID        StockItemOrder = 0
+	StockItemOrder_STOCK_ORDER_NAME      StockItemOrder = 1
+	StockItemOrder_STOCK_ORDER_AVAILABLE StockItemOrder = 2
+)
+
+// Enum value maps for StockItemOrder.
+var (
+	StockItemOrder_name = map[int32]string{
+		0: "STOCK_ORDER_ID",
+		1: "STOCK_ORDER_NAME",
+		2: "STOCK_ORDER_AVAILABLE",
+	}
+	StockItemOrder_value = map[string]int32{
+		"STOCK_ORDER_ID":        0,
+		"STOCK_ORDER_NAME":      1,
+		"STOCK_ORDER_AVAILABLE": 2,
+	}
+)
+
+func (x StockItemOrder) Enum() *StockItemOrder {
+	p := new(StockItemOrder)
+	*p = x
+	return p
+}
+
+func (x StockItemOrder) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (StockItemOrder) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_oms_proto_enumTypes[1].Descriptor()
+}
+
+func (StockItemOrder) Type() protoreflect.EnumType {
+	return &file_api_oms_proto_enumTypes[1]
+}
+
+func (x StockItemOrder) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use StockItemOrder.Descriptor instead.
+func (StockItemOrder) EnumDescriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{1}
+}
+
+type PaymentStatus int32
+
+const (
+	PaymentStatus_PAYMENT_UNKNOWN  PaymentStatus = 0
+	PaymentStatus_PAYMENT_PENDING  PaymentStatus = 1
+	PaymentStatus_PAYMENT_CAPTURED PaymentStatus = 2
+	PaymentStatus_PAYMENT_REFUNDED PaymentStatus = 3
+	PaymentStatus_PAYMENT_FAILED   PaymentStatus = 4
+)
+
+// Enum value maps for PaymentStatus.
+var (
+	PaymentStatus_name = map[int32]string{
+		0: "PAYMENT_UNKNOWN",
+		1: "PAYMENT_PENDING",
+		2: "PAYMENT_CAPTURED",
+		3: "PAYMENT_REFUNDED",
+		4: "PAYMENT_FAILED",
+	}
+	PaymentStatus_value = map[string]int32{
+		"PAYMENT_UNKNOWN":  0,
+		"PAYMENT_PENDING":  1,
+		"PAYMENT_CAPTURED": 2,
+		"PAYMENT_REFUNDED": 3,
+		"PAYMENT_FAILED":   4,
+	}
+)
+
+func (x PaymentStatus) Enum() *PaymentStatus {
+	p := new(PaymentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_oms_proto_enumTypes[2].Descriptor()
+}
+
+func (PaymentStatus) Type() protoreflect.EnumType {
+	return &file_api_oms_proto_enumTypes[2]
+}
+
+func (x PaymentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentStatus.Descriptor instead.
+func (PaymentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{2}
+}
+
+// Orders are split into one ticket per kitchen station. A ticket waits in
+// its station's queue, is started by a cook and bumped once done. An order
+// is ready when all of its tickets are bumped, and fails if one is voided.
+type TicketStatus int32
+
+const (
+	TicketStatus_TICKET_STATUS_UNKNOWN TicketStatus = 0
+	TicketStatus_TICKET_QUEUED         TicketStatus = 1
+	TicketStatus_TICKET_IN_PROGRESS    TicketStatus = 2
+	TicketStatus_TICKET_BUMPED         TicketStatus = 3
+	TicketStatus_TICKET_VOIDED         TicketStatus = 4
+)
+
+// Enum value maps for TicketStatus.
+var (
+	TicketStatus_name = map[int32]string{
+		0: "TICKET_STATUS_UNKNOWN",
+		1: "TICKET_QUEUED",
+		2: "TICKET_IN_PROGRESS",
+		3: "TICKET_BUMPED",
+		4: "TICKET_VOIDED",
+	}
+	TicketStatus_value = map[string]int32{
+		"TICKET_STATUS_UNKNOWN": 0,
+		"TICKET_QUEUED":         1,
+		"TICKET_IN_PROGRESS":    2,
+		"TICKET_BUMPED":         3,
+		"TICKET_VOIDED":         4,
+	}
+)
+
+func (x TicketStatus) Enum() *TicketStatus {
+	p := new(TicketStatus)
+	*p = x
+	return p
+}
+
+func (x TicketStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (TicketStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_api_oms_proto_enumTypes[3].Descriptor()
+}
+
+func (TicketStatus) Type() protoreflect.EnumType {
+	return &file_api_oms_proto_enumTypes[3]
+}
+
+func (x TicketStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use TicketStatus.Descriptor instead.
+func (TicketStatus) EnumDescriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{3}
+}
+
+// Money is an amount in the minor units (e.g. cents) of a currency.
+type Money struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	CustomerID    string                 `protobuf:"bytes,2,opt,name=customerID,proto3" json:"customerID,omitempty"`
-	Status        string                 `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"`
-	Items         []*Item                `protobuf:"bytes,4,rep,name=Items,proto3" json:"Items,omitempty"`
+	Currency      string                 `protobuf:"bytes,1,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	Amount        int64                  `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Money) Reset() {
+	*x = Money{}
+	mi := &file_api_oms_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Money) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Money) ProtoMessage() {}
+
+func (x *Money) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Money.ProtoReflect.Descriptor instead.
+func (*Money) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Money) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Money) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type Order struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ID         string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	CustomerID string                 `protobuf:"bytes,2,opt,name=customerID,proto3" json:"customerID,omitempty"`
+	Status     string                 `protobuf:"bytes,3,opt,name=Status,proto3" json:"Status,omitempty"`
+	Items      []*Item                `protobuf:"bytes,4,rep,name=Items,proto3" json:"Items,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	// Total is Subtotal - Discount + Tax; tax is charged on the discounted
+	// subtotal.
+	Subtotal *Money `protobuf:"bytes,6,opt,name=Subtotal,proto3" json:"Subtotal,omitempty"`
+	Discount *Money `protobuf:"bytes,7,opt,name=Discount,proto3" json:"Discount,omitempty"`
+	Tax      *Money `protobuf:"bytes,8,opt,name=Tax,proto3" json:"Tax,omitempty"`
+	Total    *Money `protobuf:"bytes,9,opt,name=Total,proto3" json:"Total,omitempty"`
+	// EstimatedReadyAt is the kitchen's estimate of when the order will be
+	// ready, set once the kitchen has accepted it.
+	EstimatedReadyAt *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=EstimatedReadyAt,proto3" json:"EstimatedReadyAt,omitempty"`
+	// VIP and Rush orders go ahead of the others in the kitchen queues when
+	// the kitchen schedules by priority, rush orders first.
+	VIP           bool `protobuf:"varint,11,opt,name=VIP,proto3" json:"VIP,omitempty"`
+	Rush          bool `protobuf:"varint,12,opt,name=Rush,proto3" json:"Rush,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Order) Reset() {
 	*x = Order{}
-	mi := &file_api_oms_proto_msgTypes[0]
+	mi := &file_api_oms_proto_msgTypes[1]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -98,7 +346,7 @@ func (x *Order) String() string {
 func (*Order) ProtoMessage() {}
 
 func (x *Order) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[0]
+	mi := &file_api_oms_proto_msgTypes[1]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -111,7 +359,7 @@ func (x *Order) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Order.ProtoReflect.Descriptor instead.
 func (*Order) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{0}
+	return file_api_oms_proto_rawDescGZIP(), []int{1}
 }
 
 func (x *Order) GetID() string {
@@ -142,19 +390,78 @@ func (x *Order) GetItems() []*Item {
 	return nil
 }
 
+func (x *Order) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Order) GetSubtotal() *Money {
+	if x != nil {
+		return x.Subtotal
+	}
+	return nil
+}
+
+func (x *Order) GetDiscount() *Money {
+	if x != nil {
+		return x.Discount
+	}
+	return nil
+}
+
+func (x *Order) GetTax() *Money {
+	if x != nil {
+		return x.Tax
+	}
+	return nil
+}
+
+func (x *Order) GetTotal() *Money {
+	if x != nil {
+		return x.Total
+	}
+	return nil
+}
+
+func (x *Order) GetEstimatedReadyAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EstimatedReadyAt
+	}
+	return nil
+}
+
+func (x *Order) GetVIP() bool {
+	if x != nil {
+		return x.VIP
+	}
+	return false
+}
+
+func (x *Order) GetRush() bool {
+	if x != nil {
+		return x.Rush
+	}
+	return false
+}
+
 type Item struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
-	PriceID       string                 `protobuf:"bytes,4,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	ID        string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Quantity  int32                  `protobuf:"varint,2,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
+	Name      string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	PriceID   string                 `protobuf:"bytes,4,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
+	UnitPrice *Money                 `protobuf:"bytes,5,opt,name=UnitPrice,proto3" json:"UnitPrice,omitempty"`
+	// Station is the kitchen station that prepares the item.
+	Station       string `protobuf:"bytes,6,opt,name=Station,proto3" json:"Station,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *Item) Reset() {
 	*x = Item{}
-	mi := &file_api_oms_proto_msgTypes[1]
+	mi := &file_api_oms_proto_msgTypes[2]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -166,7 +473,7 @@ func (x *Item) String() string {
 func (*Item) ProtoMessage() {}
 
 func (x *Item) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[1]
+	mi := &file_api_oms_proto_msgTypes[2]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -179,7 +486,7 @@ func (x *Item) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Item.ProtoReflect.Descriptor instead.
 func (*Item) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{1}
+	return file_api_oms_proto_rawDescGZIP(), []int{2}
 }
 
 func (x *Item) GetID() string {
@@ -210,6 +517,20 @@ func (x *Item) GetPriceID() string {
 	return ""
 }
 
+func (x *Item) GetUnitPrice() *Money {
+	if x != nil {
+		return x.UnitPrice
+	}
+	return nil
+}
+
+func (x *Item) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
 type ItemWithQuantity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
@@ -220,7 +541,7 @@ type ItemWithQuantity struct {
 
 func (x *ItemWithQuantity) Reset() {
 	*x = ItemWithQuantity{}
-	mi := &file_api_oms_proto_msgTypes[2]
+	mi := &file_api_oms_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -232,7 +553,7 @@ func (x *ItemWithQuantity) String() string {
 func (*ItemWithQuantity) ProtoMessage() {}
 
 func (x *ItemWithQuantity) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[2]
+	mi := &file_api_oms_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -245,7 +566,7 @@ func (x *ItemWithQuantity) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ItemWithQuantity.ProtoReflect.Descriptor instead.
 func (*ItemWithQuantity) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{2}
+	return file_api_oms_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *ItemWithQuantity) GetID() string {
@@ -263,16 +584,22 @@ func (x *ItemWithQuantity) GetQuantity() int32 {
 }
 
 type CreateOrderRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerID    string                 `protobuf:"bytes,1,opt,name=customerID,proto3" json:"customerID,omitempty"`
-	Items         []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CustomerID string                 `protobuf:"bytes,1,opt,name=customerID,proto3" json:"customerID,omitempty"`
+	Items      []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
+	PromoCode  string                 `protobuf:"bytes,3,opt,name=PromoCode,proto3" json:"PromoCode,omitempty"`
+	// IdempotencyKey, if set, makes retries of the same request return the
+	// order created by the first one instead of placing another.
+	IdempotencyKey string `protobuf:"bytes,4,opt,name=IdempotencyKey,proto3" json:"IdempotencyKey,omitempty"`
+	VIP            bool   `protobuf:"varint,5,opt,name=VIP,proto3" json:"VIP,omitempty"`
+	Rush           bool   `protobuf:"varint,6,opt,name=Rush,proto3" json:"Rush,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *CreateOrderRequest) Reset() {
 	*x = CreateOrderRequest{}
-	mi := &file_api_oms_proto_msgTypes[3]
+	mi := &file_api_oms_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -284,7 +611,7 @@ func (x *CreateOrderRequest) String() string {
 func (*CreateOrderRequest) ProtoMessage() {}
 
 func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[3]
+	mi := &file_api_oms_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -297,7 +624,7 @@ func (x *CreateOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateOrderRequest.ProtoReflect.Descriptor instead.
 func (*CreateOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{3}
+	return file_api_oms_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *CreateOrderRequest) GetCustomerID() string {
@@ -314,6 +641,34 @@ func (x *CreateOrderRequest) GetItems() []*ItemWithQuantity {
 	return nil
 }
 
+func (x *CreateOrderRequest) GetPromoCode() string {
+	if x != nil {
+		return x.PromoCode
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *CreateOrderRequest) GetVIP() bool {
+	if x != nil {
+		return x.VIP
+	}
+	return false
+}
+
+func (x *CreateOrderRequest) GetRush() bool {
+	if x != nil {
+		return x.Rush
+	}
+	return false
+}
+
 type GetOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
@@ -323,7 +678,7 @@ type GetOrderRequest struct {
 
 func (x *GetOrderRequest) Reset() {
 	*x = GetOrderRequest{}
-	mi := &file_api_oms_proto_msgTypes[4]
+	mi := &file_api_oms_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -335,7 +690,7 @@ func (x *GetOrderRequest) String() string {
 func (*GetOrderRequest) ProtoMessage() {}
 
 func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[4]
+	mi := &file_api_oms_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -348,7 +703,7 @@ func (x *GetOrderRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetOrderRequest.ProtoReflect.Descriptor instead.
 func (*GetOrderRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{4}
+	return file_api_oms_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *GetOrderRequest) GetID() string {
@@ -358,16 +713,24 @@ func (x *GetOrderRequest) GetID() string {
 	return ""
 }
 
+// GetUserOrdersRequest pages through a customer's orders, newest first.
 type GetUserOrdersRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CustomerID    string                 `protobuf:"bytes,1,opt,name=customerID,proto3" json:"customerID,omitempty"`
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	CustomerID string                 `protobuf:"bytes,1,opt,name=customerID,proto3" json:"customerID,omitempty"`
+	PageSize   int32                  `protobuf:"varint,2,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	PageToken  string                 `protobuf:"bytes,3,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	// Statuses limits the result to orders in any of the given statuses.
+	Statuses []OrderStatus `protobuf:"varint,4,rep,packed,name=Statuses,proto3,enum=api.OrderStatus" json:"Statuses,omitempty"`
+	// CreatedAfter is inclusive, CreatedBefore exclusive.
+	CreatedAfter  *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=CreatedAfter,proto3" json:"CreatedAfter,omitempty"`
+	CreatedBefore *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedBefore,proto3" json:"CreatedBefore,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *GetUserOrdersRequest) Reset() {
 	*x = GetUserOrdersRequest{}
-	mi := &file_api_oms_proto_msgTypes[5]
+	mi := &file_api_oms_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -379,7 +742,7 @@ func (x *GetUserOrdersRequest) String() string {
 func (*GetUserOrdersRequest) ProtoMessage() {}
 
 func (x *GetUserOrdersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[5]
+	mi := &file_api_oms_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -392,7 +755,7 @@ func (x *GetUserOrdersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserOrdersRequest.ProtoReflect.Descriptor instead.
 func (*GetUserOrdersRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{5}
+	return file_api_oms_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetUserOrdersRequest) GetCustomerID() string {
@@ -402,28 +765,64 @@ func (x *GetUserOrdersRequest) GetCustomerID() string {
 	return ""
 }
 
-type GetUserOrdersResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Orders        []*Order               `protobuf:"bytes,1,rep,name=Orders,proto3" json:"Orders,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *GetUserOrdersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
 }
 
-func (x *GetUserOrdersResponse) Reset() {
-	*x = GetUserOrdersResponse{}
-	mi := &file_api_oms_proto_msgTypes[6]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *GetUserOrdersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
 }
 
-func (x *GetUserOrdersResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *GetUserOrdersRequest) GetStatuses() []OrderStatus {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *GetUserOrdersRequest) GetCreatedAfter() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAfter
+	}
+	return nil
+}
+
+func (x *GetUserOrdersRequest) GetCreatedBefore() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedBefore
+	}
+	return nil
+}
+
+type GetUserOrdersResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Orders        []*Order               `protobuf:"bytes,1,rep,name=Orders,proto3" json:"Orders,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserOrdersResponse) Reset() {
+	*x = GetUserOrdersResponse{}
+	mi := &file_api_oms_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserOrdersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
 func (*GetUserOrdersResponse) ProtoMessage() {}
 
 func (x *GetUserOrdersResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[6]
+	mi := &file_api_oms_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -436,7 +835,7 @@ func (x *GetUserOrdersResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetUserOrdersResponse.ProtoReflect.Descriptor instead.
 func (*GetUserOrdersResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{6}
+	return file_api_oms_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetUserOrdersResponse) GetOrders() []*Order {
@@ -446,17 +845,27 @@ func (x *GetUserOrdersResponse) GetOrders() []*Order {
 	return nil
 }
 
+func (x *GetUserOrdersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 type PatchOrderStatusRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderID       string                 `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
-	Status        OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.OrderStatus" json:"status,omitempty"`
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderID string                 `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Status  OrderStatus            `protobuf:"varint,2,opt,name=status,proto3,enum=api.OrderStatus" json:"status,omitempty"`
+	// Who or what requested the change, e.g. "kitchen" or "customer:<id>".
+	TriggeredBy   string `protobuf:"bytes,3,opt,name=triggeredBy,proto3" json:"triggeredBy,omitempty"`
+	Reason        string `protobuf:"bytes,4,opt,name=reason,proto3" json:"reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PatchOrderStatusRequest) Reset() {
 	*x = PatchOrderStatusRequest{}
-	mi := &file_api_oms_proto_msgTypes[7]
+	mi := &file_api_oms_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -468,7 +877,7 @@ func (x *PatchOrderStatusRequest) String() string {
 func (*PatchOrderStatusRequest) ProtoMessage() {}
 
 func (x *PatchOrderStatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[7]
+	mi := &file_api_oms_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -481,7 +890,7 @@ func (x *PatchOrderStatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PatchOrderStatusRequest.ProtoReflect.Descriptor instead.
 func (*PatchOrderStatusRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{7}
+	return file_api_oms_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *PatchOrderStatusRequest) GetOrderID() string {
@@ -498,23 +907,240 @@ func (x *PatchOrderStatusRequest) GetStatus() OrderStatus {
 	return OrderStatus_UNKNOWN
 }
 
-type StockItem struct {
+func (x *PatchOrderStatusRequest) GetTriggeredBy() string {
+	if x != nil {
+		return x.TriggeredBy
+	}
+	return ""
+}
+
+func (x *PatchOrderStatusRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type WatchOrderRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderID string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	// AfterSequence resumes a watch after the event with that Sequence.
+	AfterSequence int64 `protobuf:"varint,2,opt,name=AfterSequence,proto3" json:"AfterSequence,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchOrderRequest) Reset() {
+	*x = WatchOrderRequest{}
+	mi := &file_api_oms_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchOrderRequest) ProtoMessage() {}
+
+func (x *WatchOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchOrderRequest.ProtoReflect.Descriptor instead.
+func (*WatchOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchOrderRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *WatchOrderRequest) GetAfterSequence() int64 {
+	if x != nil {
+		return x.AfterSequence
+	}
+	return 0
+}
+
+type OrderStatusEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Sequence increases with every status change of any order.
+	Sequence      int64                  `protobuf:"varint,1,opt,name=Sequence,proto3" json:"Sequence,omitempty"`
+	OrderID       string                 `protobuf:"bytes,2,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	From          OrderStatus            `protobuf:"varint,3,opt,name=From,proto3,enum=api.OrderStatus" json:"From,omitempty"`
+	To            OrderStatus            `protobuf:"varint,4,opt,name=To,proto3,enum=api.OrderStatus" json:"To,omitempty"`
+	TriggeredBy   string                 `protobuf:"bytes,5,opt,name=TriggeredBy,proto3" json:"TriggeredBy,omitempty"`
+	Reason        string                 `protobuf:"bytes,6,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	At            *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=At,proto3" json:"At,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *OrderStatusEvent) Reset() {
+	*x = OrderStatusEvent{}
+	mi := &file_api_oms_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *OrderStatusEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*OrderStatusEvent) ProtoMessage() {}
+
+func (x *OrderStatusEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use OrderStatusEvent.ProtoReflect.Descriptor instead.
+func (*OrderStatusEvent) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *OrderStatusEvent) GetSequence() int64 {
+	if x != nil {
+		return x.Sequence
+	}
+	return 0
+}
+
+func (x *OrderStatusEvent) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetFrom() OrderStatus {
+	if x != nil {
+		return x.From
+	}
+	return OrderStatus_UNKNOWN
+}
+
+func (x *OrderStatusEvent) GetTo() OrderStatus {
+	if x != nil {
+		return x.To
+	}
+	return OrderStatus_UNKNOWN
+}
+
+func (x *OrderStatusEvent) GetTriggeredBy() string {
+	if x != nil {
+		return x.TriggeredBy
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *OrderStatusEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+type CancelOrderRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
-	Quantity      int32                  `protobuf:"varint,2,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
-	PriceID       string                 `protobuf:"bytes,4,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
-	Description   string                 `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
-	ImgPath       string                 `protobuf:"bytes,6,opt,name=ImgPath,proto3" json:"ImgPath,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	OrderID       string                 `protobuf:"bytes,1,opt,name=orderID,proto3" json:"orderID,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelOrderRequest) Reset() {
+	*x = CancelOrderRequest{}
+	mi := &file_api_oms_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelOrderRequest) ProtoMessage() {}
+
+func (x *CancelOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelOrderRequest.ProtoReflect.Descriptor instead.
+func (*CancelOrderRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *CancelOrderRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *CancelOrderRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type StockItem struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	ID          string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Quantity    int32                  `protobuf:"varint,2,opt,name=Quantity,proto3" json:"Quantity,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=Name,proto3" json:"Name,omitempty"`
+	PriceID     string                 `protobuf:"bytes,4,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
+	Description string                 `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
+	ImgPath     string                 `protobuf:"bytes,6,opt,name=ImgPath,proto3" json:"ImgPath,omitempty"`
+	CreatedAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	// Available is Quantity minus the active bookings of the item.
+	Available int32  `protobuf:"varint,9,opt,name=Available,proto3" json:"Available,omitempty"`
+	Price     *Money `protobuf:"bytes,10,opt,name=Price,proto3" json:"Price,omitempty"`
+	// Station is the kitchen station that prepares the item, e.g. "grill".
+	Station       string `protobuf:"bytes,11,opt,name=Station,proto3" json:"Station,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *StockItem) Reset() {
 	*x = StockItem{}
-	mi := &file_api_oms_proto_msgTypes[8]
+	mi := &file_api_oms_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -526,7 +1152,7 @@ func (x *StockItem) String() string {
 func (*StockItem) ProtoMessage() {}
 
 func (x *StockItem) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[8]
+	mi := &file_api_oms_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -539,7 +1165,7 @@ func (x *StockItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StockItem.ProtoReflect.Descriptor instead.
 func (*StockItem) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{8}
+	return file_api_oms_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *StockItem) GetID() string {
@@ -598,6 +1224,27 @@ func (x *StockItem) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *StockItem) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+func (x *StockItem) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *StockItem) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
 type BookedItem struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	BookingID     string                 `protobuf:"bytes,1,opt,name=BookingID,proto3" json:"BookingID,omitempty"`
@@ -612,7 +1259,7 @@ type BookedItem struct {
 
 func (x *BookedItem) Reset() {
 	*x = BookedItem{}
-	mi := &file_api_oms_proto_msgTypes[9]
+	mi := &file_api_oms_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -624,7 +1271,7 @@ func (x *BookedItem) String() string {
 func (*BookedItem) ProtoMessage() {}
 
 func (x *BookedItem) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[9]
+	mi := &file_api_oms_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -637,7 +1284,7 @@ func (x *BookedItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use BookedItem.ProtoReflect.Descriptor instead.
 func (*BookedItem) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{9}
+	return file_api_oms_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *BookedItem) GetBookingID() string {
@@ -690,13 +1337,15 @@ type AddStockItemRequest struct {
 	PriceID       string                 `protobuf:"bytes,4,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
 	Description   string                 `protobuf:"bytes,5,opt,name=Description,proto3" json:"Description,omitempty"`
 	ImgPath       string                 `protobuf:"bytes,6,opt,name=ImgPath,proto3" json:"ImgPath,omitempty"`
+	Price         *Money                 `protobuf:"bytes,7,opt,name=Price,proto3" json:"Price,omitempty"`
+	Station       string                 `protobuf:"bytes,8,opt,name=Station,proto3" json:"Station,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *AddStockItemRequest) Reset() {
 	*x = AddStockItemRequest{}
-	mi := &file_api_oms_proto_msgTypes[10]
+	mi := &file_api_oms_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -708,7 +1357,7 @@ func (x *AddStockItemRequest) String() string {
 func (*AddStockItemRequest) ProtoMessage() {}
 
 func (x *AddStockItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[10]
+	mi := &file_api_oms_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -721,7 +1370,7 @@ func (x *AddStockItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddStockItemRequest.ProtoReflect.Descriptor instead.
 func (*AddStockItemRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{10}
+	return file_api_oms_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *AddStockItemRequest) GetID() string {
@@ -766,6 +1415,20 @@ func (x *AddStockItemRequest) GetImgPath() string {
 	return ""
 }
 
+func (x *AddStockItemRequest) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *AddStockItemRequest) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
 type AddStockItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Item          *StockItem             `protobuf:"bytes,1,opt,name=Item,proto3" json:"Item,omitempty"`
@@ -775,7 +1438,7 @@ type AddStockItemResponse struct {
 
 func (x *AddStockItemResponse) Reset() {
 	*x = AddStockItemResponse{}
-	mi := &file_api_oms_proto_msgTypes[11]
+	mi := &file_api_oms_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -787,7 +1450,7 @@ func (x *AddStockItemResponse) String() string {
 func (*AddStockItemResponse) ProtoMessage() {}
 
 func (x *AddStockItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[11]
+	mi := &file_api_oms_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -800,7 +1463,7 @@ func (x *AddStockItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AddStockItemResponse.ProtoReflect.Descriptor instead.
 func (*AddStockItemResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{11}
+	return file_api_oms_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *AddStockItemResponse) GetItem() *StockItem {
@@ -820,7 +1483,7 @@ type RemoveStockItemRequest struct {
 
 func (x *RemoveStockItemRequest) Reset() {
 	*x = RemoveStockItemRequest{}
-	mi := &file_api_oms_proto_msgTypes[12]
+	mi := &file_api_oms_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -832,7 +1495,7 @@ func (x *RemoveStockItemRequest) String() string {
 func (*RemoveStockItemRequest) ProtoMessage() {}
 
 func (x *RemoveStockItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[12]
+	mi := &file_api_oms_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -845,7 +1508,7 @@ func (x *RemoveStockItemRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveStockItemRequest.ProtoReflect.Descriptor instead.
 func (*RemoveStockItemRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{12}
+	return file_api_oms_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *RemoveStockItemRequest) GetID() string {
@@ -871,7 +1534,7 @@ type RemoveStockItemResponse struct {
 
 func (x *RemoveStockItemResponse) Reset() {
 	*x = RemoveStockItemResponse{}
-	mi := &file_api_oms_proto_msgTypes[13]
+	mi := &file_api_oms_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -883,7 +1546,7 @@ func (x *RemoveStockItemResponse) String() string {
 func (*RemoveStockItemResponse) ProtoMessage() {}
 
 func (x *RemoveStockItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[13]
+	mi := &file_api_oms_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -896,7 +1559,7 @@ func (x *RemoveStockItemResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RemoveStockItemResponse.ProtoReflect.Descriptor instead.
 func (*RemoveStockItemResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{13}
+	return file_api_oms_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RemoveStockItemResponse) GetItem() *StockItem {
@@ -907,28 +1570,2239 @@ func (x *RemoveStockItemResponse) GetItem() *StockItem {
 }
 
 type BookItemsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderID string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	Items   []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
+	// How long the booking is held; the stock service default applies if unset.
+	TTL           *durationpb.Duration `protobuf:"bytes,3,opt,name=TTL,proto3" json:"TTL,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookItemsRequest) Reset() {
+	*x = BookItemsRequest{}
+	mi := &file_api_oms_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookItemsRequest) ProtoMessage() {}
+
+func (x *BookItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookItemsRequest.ProtoReflect.Descriptor instead.
+func (*BookItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *BookItemsRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *BookItemsRequest) GetItems() []*ItemWithQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BookItemsRequest) GetTTL() *durationpb.Duration {
+	if x != nil {
+		return x.TTL
+	}
+	return nil
+}
+
+type BookItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Bookings      []*ItemWithQuantity    `protobuf:"bytes,1,rep,name=Bookings,proto3" json:"Bookings,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookItemsResponse) Reset() {
+	*x = BookItemsResponse{}
+	mi := &file_api_oms_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookItemsResponse) ProtoMessage() {}
+
+func (x *BookItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookItemsResponse.ProtoReflect.Descriptor instead.
+func (*BookItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *BookItemsResponse) GetBookings() []*ItemWithQuantity {
+	if x != nil {
+		return x.Bookings
+	}
+	return nil
+}
+
+type ReleaseBookedItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	Items         []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseBookedItemsRequest) Reset() {
+	*x = ReleaseBookedItemsRequest{}
+	mi := &file_api_oms_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseBookedItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseBookedItemsRequest) ProtoMessage() {}
+
+func (x *ReleaseBookedItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseBookedItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReleaseBookedItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ReleaseBookedItemsRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *ReleaseBookedItemsRequest) GetItems() []*ItemWithQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ReleaseBookedItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReleaseBookedItemsResponse) Reset() {
+	*x = ReleaseBookedItemsResponse{}
+	mi := &file_api_oms_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReleaseBookedItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReleaseBookedItemsResponse) ProtoMessage() {}
+
+func (x *ReleaseBookedItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReleaseBookedItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReleaseBookedItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ReleaseBookedItemsResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type BookingExpiredEvent struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
 	Items         []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
+	ExpiredAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ExpiredAt,proto3" json:"ExpiredAt,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BookingExpiredEvent) Reset() {
+	*x = BookingExpiredEvent{}
+	mi := &file_api_oms_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BookingExpiredEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BookingExpiredEvent) ProtoMessage() {}
+
+func (x *BookingExpiredEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BookingExpiredEvent.ProtoReflect.Descriptor instead.
+func (*BookingExpiredEvent) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BookingExpiredEvent) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *BookingExpiredEvent) GetItems() []*ItemWithQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *BookingExpiredEvent) GetExpiredAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiredAt
+	}
+	return nil
+}
+
+type VerifyStockRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemWithQuantity    `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *BookItemsRequest) Reset() {
-	*x = BookItemsRequest{}
-	mi := &file_api_oms_proto_msgTypes[14]
+func (x *VerifyStockRequest) Reset() {
+	*x = VerifyStockRequest{}
+	mi := &file_api_oms_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyStockRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyStockRequest) ProtoMessage() {}
+
+func (x *VerifyStockRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyStockRequest.ProtoReflect.Descriptor instead.
+func (*VerifyStockRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *VerifyStockRequest) GetItems() []*ItemWithQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type VerifyStockResponse struct {
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	AllAvailable          bool                   `protobuf:"varint,1,opt,name=all_available,json=allAvailable,proto3" json:"all_available,omitempty"`
+	MissingOrInsufficient []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=missing_or_insufficient,json=missingOrInsufficient,proto3" json:"missing_or_insufficient,omitempty"`
+	Shortages             []*StockShortage       `protobuf:"bytes,3,rep,name=shortages,proto3" json:"shortages,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
+}
+
+func (x *VerifyStockResponse) Reset() {
+	*x = VerifyStockResponse{}
+	mi := &file_api_oms_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyStockResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyStockResponse) ProtoMessage() {}
+
+func (x *VerifyStockResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyStockResponse.ProtoReflect.Descriptor instead.
+func (*VerifyStockResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *VerifyStockResponse) GetAllAvailable() bool {
+	if x != nil {
+		return x.AllAvailable
+	}
+	return false
+}
+
+func (x *VerifyStockResponse) GetMissingOrInsufficient() []*ItemWithQuantity {
+	if x != nil {
+		return x.MissingOrInsufficient
+	}
+	return nil
+}
+
+func (x *VerifyStockResponse) GetShortages() []*StockShortage {
+	if x != nil {
+		return x.Shortages
+	}
+	return nil
+}
+
+type StockShortage struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ItemID        string                 `protobuf:"bytes,1,opt,name=ItemID,proto3" json:"ItemID,omitempty"`
+	Requested     int32                  `protobuf:"varint,2,opt,name=Requested,proto3" json:"Requested,omitempty"`
+	Available     int32                  `protobuf:"varint,3,opt,name=Available,proto3" json:"Available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StockShortage) Reset() {
+	*x = StockShortage{}
+	mi := &file_api_oms_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StockShortage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StockShortage) ProtoMessage() {}
+
+func (x *StockShortage) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StockShortage.ProtoReflect.Descriptor instead.
+func (*StockShortage) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *StockShortage) GetItemID() string {
+	if x != nil {
+		return x.ItemID
+	}
+	return ""
+}
+
+func (x *StockShortage) GetRequested() int32 {
+	if x != nil {
+		return x.Requested
+	}
+	return 0
+}
+
+func (x *StockShortage) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type GetStockItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemRequest) Reset() {
+	*x = GetStockItemRequest{}
+	mi := &file_api_oms_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemRequest) ProtoMessage() {}
+
+func (x *GetStockItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemRequest.ProtoReflect.Descriptor instead.
+func (*GetStockItemRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *GetStockItemRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+type GetStockItemResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *StockItem             `protobuf:"bytes,1,opt,name=Item,proto3" json:"Item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemResponse) Reset() {
+	*x = GetStockItemResponse{}
+	mi := &file_api_oms_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemResponse) ProtoMessage() {}
+
+func (x *GetStockItemResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemResponse.ProtoReflect.Descriptor instead.
+func (*GetStockItemResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetStockItemResponse) GetItem() *StockItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+type GetStockItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	IDs           []string               `protobuf:"bytes,1,rep,name=IDs,proto3" json:"IDs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemsRequest) Reset() {
+	*x = GetStockItemsRequest{}
+	mi := &file_api_oms_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemsRequest) ProtoMessage() {}
+
+func (x *GetStockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetStockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetStockItemsRequest) GetIDs() []string {
+	if x != nil {
+		return x.IDs
+	}
+	return nil
+}
+
+type GetStockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetStockItemsResponse) Reset() {
+	*x = GetStockItemsResponse{}
+	mi := &file_api_oms_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetStockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetStockItemsResponse) ProtoMessage() {}
+
+func (x *GetStockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetStockItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetStockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *GetStockItemsResponse) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type FinalizeBookingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinalizeBookingRequest) Reset() {
+	*x = FinalizeBookingRequest{}
+	mi := &file_api_oms_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeBookingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeBookingRequest) ProtoMessage() {}
+
+func (x *FinalizeBookingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeBookingRequest.ProtoReflect.Descriptor instead.
+func (*FinalizeBookingRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FinalizeBookingRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+type FinalizeBookingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinalizeBookingResponse) Reset() {
+	*x = FinalizeBookingResponse{}
+	mi := &file_api_oms_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinalizeBookingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinalizeBookingResponse) ProtoMessage() {}
+
+func (x *FinalizeBookingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinalizeBookingResponse.ProtoReflect.Descriptor instead.
+func (*FinalizeBookingResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *FinalizeBookingResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+type ReturnFinalizedItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnFinalizedItemsRequest) Reset() {
+	*x = ReturnFinalizedItemsRequest{}
+	mi := &file_api_oms_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnFinalizedItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnFinalizedItemsRequest) ProtoMessage() {}
+
+func (x *ReturnFinalizedItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnFinalizedItemsRequest.ProtoReflect.Descriptor instead.
+func (*ReturnFinalizedItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ReturnFinalizedItemsRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+// Items lists the stock put back. It is empty if the order's booking was
+// never finalized or its stock was already returned.
+type ReturnFinalizedItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*ItemWithQuantity    `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReturnFinalizedItemsResponse) Reset() {
+	*x = ReturnFinalizedItemsResponse{}
+	mi := &file_api_oms_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReturnFinalizedItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReturnFinalizedItemsResponse) ProtoMessage() {}
+
+func (x *ReturnFinalizedItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReturnFinalizedItemsResponse.ProtoReflect.Descriptor instead.
+func (*ReturnFinalizedItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ReturnFinalizedItemsResponse) GetItems() []*ItemWithQuantity {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type ListStockItemsRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PageSize  int32                  `protobuf:"varint,1,opt,name=PageSize,proto3" json:"PageSize,omitempty"`
+	PageToken string                 `protobuf:"bytes,2,opt,name=PageToken,proto3" json:"PageToken,omitempty"`
+	// NameQuery matches items whose name contains it, ignoring case.
+	NameQuery     string         `protobuf:"bytes,3,opt,name=NameQuery,proto3" json:"NameQuery,omitempty"`
+	InStockOnly   bool           `protobuf:"varint,4,opt,name=InStockOnly,proto3" json:"InStockOnly,omitempty"`
+	OrderBy       StockItemOrder `protobuf:"varint,5,opt,name=OrderBy,proto3,enum=api.StockItemOrder" json:"OrderBy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockItemsRequest) Reset() {
+	*x = ListStockItemsRequest{}
+	mi := &file_api_oms_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockItemsRequest) ProtoMessage() {}
+
+func (x *ListStockItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListStockItemsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ListStockItemsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListStockItemsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListStockItemsRequest) GetNameQuery() string {
+	if x != nil {
+		return x.NameQuery
+	}
+	return ""
+}
+
+func (x *ListStockItemsRequest) GetInStockOnly() bool {
+	if x != nil {
+		return x.InStockOnly
+	}
+	return false
+}
+
+func (x *ListStockItemsRequest) GetOrderBy() StockItemOrder {
+	if x != nil {
+		return x.OrderBy
+	}
+	return StockItemOrder_STOCK_ORDER_ID
+}
+
+type ListStockItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*StockItem           `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=NextPageToken,proto3" json:"NextPageToken,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStockItemsResponse) Reset() {
+	*x = ListStockItemsResponse{}
+	mi := &file_api_oms_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStockItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStockItemsResponse) ProtoMessage() {}
+
+func (x *ListStockItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStockItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListStockItemsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *ListStockItemsResponse) GetItems() []*StockItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListStockItemsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type AdjustStockQuantityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Delta         int32                  `protobuf:"varint,2,opt,name=Delta,proto3" json:"Delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockQuantityRequest) Reset() {
+	*x = AdjustStockQuantityRequest{}
+	mi := &file_api_oms_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockQuantityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockQuantityRequest) ProtoMessage() {}
+
+func (x *AdjustStockQuantityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockQuantityRequest.ProtoReflect.Descriptor instead.
+func (*AdjustStockQuantityRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *AdjustStockQuantityRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *AdjustStockQuantityRequest) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type AdjustStockQuantityResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Item          *StockItem             `protobuf:"bytes,1,opt,name=Item,proto3" json:"Item,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AdjustStockQuantityResponse) Reset() {
+	*x = AdjustStockQuantityResponse{}
+	mi := &file_api_oms_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AdjustStockQuantityResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AdjustStockQuantityResponse) ProtoMessage() {}
+
+func (x *AdjustStockQuantityResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AdjustStockQuantityResponse.ProtoReflect.Descriptor instead.
+func (*AdjustStockQuantityResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *AdjustStockQuantityResponse) GetItem() *StockItem {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+// PriceList assigns amounts to PriceIDs. When several lists price the same
+// PriceID at a given moment, the one with the highest Priority wins, ties
+// going to the most recently effective one.
+type PriceList struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	ID       string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	Name     string                 `protobuf:"bytes,2,opt,name=Name,proto3" json:"Name,omitempty"`
+	Currency string                 `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	Priority int32                  `protobuf:"varint,4,opt,name=Priority,proto3" json:"Priority,omitempty"`
+	// The list applies from EffectiveFrom (inclusive) until EffectiveTo
+	// (exclusive); either may be unset for an open range.
+	EffectiveFrom *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=EffectiveFrom,proto3" json:"EffectiveFrom,omitempty"`
+	EffectiveTo   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=EffectiveTo,proto3" json:"EffectiveTo,omitempty"`
+	// DailyFrom and DailyTo ("HH:MM", pricing service time zone) restrict
+	// the list to a time of day, e.g. a happy hour. The window may wrap
+	// around midnight. Both empty means all day.
+	DailyFrom     string            `protobuf:"bytes,7,opt,name=DailyFrom,proto3" json:"DailyFrom,omitempty"`
+	DailyTo       string            `protobuf:"bytes,8,opt,name=DailyTo,proto3" json:"DailyTo,omitempty"`
+	Entries       []*PriceListEntry `protobuf:"bytes,9,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceList) Reset() {
+	*x = PriceList{}
+	mi := &file_api_oms_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceList) ProtoMessage() {}
+
+func (x *PriceList) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceList.ProtoReflect.Descriptor instead.
+func (*PriceList) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *PriceList) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *PriceList) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *PriceList) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *PriceList) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *PriceList) GetEffectiveFrom() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveFrom
+	}
+	return nil
+}
+
+func (x *PriceList) GetEffectiveTo() *timestamppb.Timestamp {
+	if x != nil {
+		return x.EffectiveTo
+	}
+	return nil
+}
+
+func (x *PriceList) GetDailyFrom() string {
+	if x != nil {
+		return x.DailyFrom
+	}
+	return ""
+}
+
+func (x *PriceList) GetDailyTo() string {
+	if x != nil {
+		return x.DailyTo
+	}
+	return ""
+}
+
+func (x *PriceList) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type PriceListEntry struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	PriceID string                 `protobuf:"bytes,1,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
+	// Amount in minor units of the list's currency.
+	Amount        int64 `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PriceListEntry) Reset() {
+	*x = PriceListEntry{}
+	mi := &file_api_oms_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PriceListEntry) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PriceListEntry) ProtoMessage() {}
+
+func (x *PriceListEntry) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PriceListEntry.ProtoReflect.Descriptor instead.
+func (*PriceListEntry) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *PriceListEntry) GetPriceID() string {
+	if x != nil {
+		return x.PriceID
+	}
+	return ""
+}
+
+func (x *PriceListEntry) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type CreatePriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceList     *PriceList             `protobuf:"bytes,1,opt,name=PriceList,proto3" json:"PriceList,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePriceListRequest) Reset() {
+	*x = CreatePriceListRequest{}
+	mi := &file_api_oms_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePriceListRequest) ProtoMessage() {}
+
+func (x *CreatePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePriceListRequest.ProtoReflect.Descriptor instead.
+func (*CreatePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *CreatePriceListRequest) GetPriceList() *PriceList {
+	if x != nil {
+		return x.PriceList
+	}
+	return nil
+}
+
+type SetPricesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceListID   string                 `protobuf:"bytes,1,opt,name=PriceListID,proto3" json:"PriceListID,omitempty"`
+	Entries       []*PriceListEntry      `protobuf:"bytes,2,rep,name=Entries,proto3" json:"Entries,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetPricesRequest) Reset() {
+	*x = SetPricesRequest{}
+	mi := &file_api_oms_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetPricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetPricesRequest) ProtoMessage() {}
+
+func (x *SetPricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetPricesRequest.ProtoReflect.Descriptor instead.
+func (*SetPricesRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SetPricesRequest) GetPriceListID() string {
+	if x != nil {
+		return x.PriceListID
+	}
+	return ""
+}
+
+func (x *SetPricesRequest) GetEntries() []*PriceListEntry {
+	if x != nil {
+		return x.Entries
+	}
+	return nil
+}
+
+type ListPriceListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsRequest) Reset() {
+	*x = ListPriceListsRequest{}
+	mi := &file_api_oms_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsRequest) ProtoMessage() {}
+
+func (x *ListPriceListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsRequest.ProtoReflect.Descriptor instead.
+func (*ListPriceListsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{42}
+}
+
+type ListPriceListsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceLists    []*PriceList           `protobuf:"bytes,1,rep,name=PriceLists,proto3" json:"PriceLists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListPriceListsResponse) Reset() {
+	*x = ListPriceListsResponse{}
+	mi := &file_api_oms_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListPriceListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListPriceListsResponse) ProtoMessage() {}
+
+func (x *ListPriceListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListPriceListsResponse.ProtoReflect.Descriptor instead.
+func (*ListPriceListsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *ListPriceListsResponse) GetPriceLists() []*PriceList {
+	if x != nil {
+		return x.PriceLists
+	}
+	return nil
+}
+
+type DeletePriceListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePriceListRequest) Reset() {
+	*x = DeletePriceListRequest{}
+	mi := &file_api_oms_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePriceListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePriceListRequest) ProtoMessage() {}
+
+func (x *DeletePriceListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePriceListRequest.ProtoReflect.Descriptor instead.
+func (*DeletePriceListRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *DeletePriceListRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+type DeletePriceListResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeletePriceListResponse) Reset() {
+	*x = DeletePriceListResponse{}
+	mi := &file_api_oms_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeletePriceListResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeletePriceListResponse) ProtoMessage() {}
+
+func (x *DeletePriceListResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeletePriceListResponse.ProtoReflect.Descriptor instead.
+func (*DeletePriceListResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{45}
+}
+
+type ResolvePricesRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	PriceIDs []string               `protobuf:"bytes,1,rep,name=PriceIDs,proto3" json:"PriceIDs,omitempty"`
+	// At defaults to now.
+	At *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=At,proto3" json:"At,omitempty"`
+	// Currency, if set, only considers lists in that currency.
+	Currency      string `protobuf:"bytes,3,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePricesRequest) Reset() {
+	*x = ResolvePricesRequest{}
+	mi := &file_api_oms_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePricesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePricesRequest) ProtoMessage() {}
+
+func (x *ResolvePricesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePricesRequest.ProtoReflect.Descriptor instead.
+func (*ResolvePricesRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *ResolvePricesRequest) GetPriceIDs() []string {
+	if x != nil {
+		return x.PriceIDs
+	}
+	return nil
+}
+
+func (x *ResolvePricesRequest) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
+}
+
+func (x *ResolvePricesRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type ResolvedPrice struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PriceID       string                 `protobuf:"bytes,1,opt,name=PriceID,proto3" json:"PriceID,omitempty"`
+	Price         *Money                 `protobuf:"bytes,2,opt,name=Price,proto3" json:"Price,omitempty"`
+	PriceListID   string                 `protobuf:"bytes,3,opt,name=PriceListID,proto3" json:"PriceListID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvedPrice) Reset() {
+	*x = ResolvedPrice{}
+	mi := &file_api_oms_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvedPrice) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvedPrice) ProtoMessage() {}
+
+func (x *ResolvedPrice) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvedPrice.ProtoReflect.Descriptor instead.
+func (*ResolvedPrice) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *ResolvedPrice) GetPriceID() string {
+	if x != nil {
+		return x.PriceID
+	}
+	return ""
+}
+
+func (x *ResolvedPrice) GetPrice() *Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *ResolvedPrice) GetPriceListID() string {
+	if x != nil {
+		return x.PriceListID
+	}
+	return ""
+}
+
+type ResolvePricesResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Prices holds an entry for every PriceID that has a price at At.
+	Prices        []*ResolvedPrice `protobuf:"bytes,1,rep,name=Prices,proto3" json:"Prices,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResolvePricesResponse) Reset() {
+	*x = ResolvePricesResponse{}
+	mi := &file_api_oms_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResolvePricesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResolvePricesResponse) ProtoMessage() {}
+
+func (x *ResolvePricesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResolvePricesResponse.ProtoReflect.Descriptor instead.
+func (*ResolvePricesResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *ResolvePricesResponse) GetPrices() []*ResolvedPrice {
+	if x != nil {
+		return x.Prices
+	}
+	return nil
+}
+
+type Payment struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	ID             string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	OrderID        string                 `protobuf:"bytes,2,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	CustomerID     string                 `protobuf:"bytes,3,opt,name=CustomerID,proto3" json:"CustomerID,omitempty"`
+	Amount         int64                  `protobuf:"varint,4,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	RefundedAmount int64                  `protobuf:"varint,5,opt,name=RefundedAmount,proto3" json:"RefundedAmount,omitempty"`
+	Currency       string                 `protobuf:"bytes,6,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	Status         string                 `protobuf:"bytes,7,opt,name=Status,proto3" json:"Status,omitempty"`
+	Provider       string                 `protobuf:"bytes,8,opt,name=Provider,proto3" json:"Provider,omitempty"`
+	ProviderRef    string                 `protobuf:"bytes,9,opt,name=ProviderRef,proto3" json:"ProviderRef,omitempty"`
+	CreatedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	UpdatedAt      *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=UpdatedAt,proto3" json:"UpdatedAt,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *Payment) Reset() {
+	*x = Payment{}
+	mi := &file_api_oms_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Payment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Payment) ProtoMessage() {}
+
+func (x *Payment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Payment.ProtoReflect.Descriptor instead.
+func (*Payment) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *Payment) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Payment) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *Payment) GetCustomerID() string {
+	if x != nil {
+		return x.CustomerID
+	}
+	return ""
+}
+
+func (x *Payment) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Payment) GetRefundedAmount() int64 {
+	if x != nil {
+		return x.RefundedAmount
+	}
+	return 0
+}
+
+func (x *Payment) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+func (x *Payment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+func (x *Payment) GetProvider() string {
+	if x != nil {
+		return x.Provider
+	}
+	return ""
+}
+
+func (x *Payment) GetProviderRef() string {
+	if x != nil {
+		return x.ProviderRef
+	}
+	return ""
+}
+
+func (x *Payment) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Payment) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type CreatePaymentIntentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	CustomerID    string                 `protobuf:"bytes,2,opt,name=CustomerID,proto3" json:"CustomerID,omitempty"`
+	Amount        int64                  `protobuf:"varint,3,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	Currency      string                 `protobuf:"bytes,4,opt,name=Currency,proto3" json:"Currency,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentIntentRequest) Reset() {
+	*x = CreatePaymentIntentRequest{}
+	mi := &file_api_oms_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentIntentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentIntentRequest) ProtoMessage() {}
+
+func (x *CreatePaymentIntentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentIntentRequest.ProtoReflect.Descriptor instead.
+func (*CreatePaymentIntentRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *CreatePaymentIntentRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetCustomerID() string {
+	if x != nil {
+		return x.CustomerID
+	}
+	return ""
+}
+
+func (x *CreatePaymentIntentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *CreatePaymentIntentRequest) GetCurrency() string {
+	if x != nil {
+		return x.Currency
+	}
+	return ""
+}
+
+type CreatePaymentIntentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=Payment,proto3" json:"Payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreatePaymentIntentResponse) Reset() {
+	*x = CreatePaymentIntentResponse{}
+	mi := &file_api_oms_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreatePaymentIntentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreatePaymentIntentResponse) ProtoMessage() {}
+
+func (x *CreatePaymentIntentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreatePaymentIntentResponse.ProtoReflect.Descriptor instead.
+func (*CreatePaymentIntentResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *CreatePaymentIntentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type CapturePaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentID     string                 `protobuf:"bytes,1,opt,name=PaymentID,proto3" json:"PaymentID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentRequest) Reset() {
+	*x = CapturePaymentRequest{}
+	mi := &file_api_oms_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentRequest) ProtoMessage() {}
+
+func (x *CapturePaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentRequest.ProtoReflect.Descriptor instead.
+func (*CapturePaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *CapturePaymentRequest) GetPaymentID() string {
+	if x != nil {
+		return x.PaymentID
+	}
+	return ""
+}
+
+type CapturePaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=Payment,proto3" json:"Payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CapturePaymentResponse) Reset() {
+	*x = CapturePaymentResponse{}
+	mi := &file_api_oms_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CapturePaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CapturePaymentResponse) ProtoMessage() {}
+
+func (x *CapturePaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CapturePaymentResponse.ProtoReflect.Descriptor instead.
+func (*CapturePaymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CapturePaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type RefundPaymentRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	PaymentID string                 `protobuf:"bytes,1,opt,name=PaymentID,proto3" json:"PaymentID,omitempty"`
+	// Amount to refund in minor units; 0 refunds whatever is left.
+	Amount        int64 `protobuf:"varint,2,opt,name=Amount,proto3" json:"Amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentRequest) Reset() {
+	*x = RefundPaymentRequest{}
+	mi := &file_api_oms_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentRequest) ProtoMessage() {}
+
+func (x *RefundPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentRequest.ProtoReflect.Descriptor instead.
+func (*RefundPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *RefundPaymentRequest) GetPaymentID() string {
+	if x != nil {
+		return x.PaymentID
+	}
+	return ""
+}
+
+func (x *RefundPaymentRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+type RefundPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=Payment,proto3" json:"Payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundPaymentResponse) Reset() {
+	*x = RefundPaymentResponse{}
+	mi := &file_api_oms_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundPaymentResponse) ProtoMessage() {}
+
+func (x *RefundPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundPaymentResponse.ProtoReflect.Descriptor instead.
+func (*RefundPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *RefundPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type VoidPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PaymentID     string                 `protobuf:"bytes,1,opt,name=PaymentID,proto3" json:"PaymentID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidPaymentRequest) Reset() {
+	*x = VoidPaymentRequest{}
+	mi := &file_api_oms_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidPaymentRequest) ProtoMessage() {}
+
+func (x *VoidPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidPaymentRequest.ProtoReflect.Descriptor instead.
+func (*VoidPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *VoidPaymentRequest) GetPaymentID() string {
+	if x != nil {
+		return x.PaymentID
+	}
+	return ""
+}
+
+type VoidPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=Payment,proto3" json:"Payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VoidPaymentResponse) Reset() {
+	*x = VoidPaymentResponse{}
+	mi := &file_api_oms_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VoidPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VoidPaymentResponse) ProtoMessage() {}
+
+func (x *VoidPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VoidPaymentResponse.ProtoReflect.Descriptor instead.
+func (*VoidPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *VoidPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+type GetPaymentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	OrderID       string                 `protobuf:"bytes,2,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentRequest) Reset() {
+	*x = GetPaymentRequest{}
+	mi := &file_api_oms_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentRequest) ProtoMessage() {}
+
+func (x *GetPaymentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentRequest.ProtoReflect.Descriptor instead.
+func (*GetPaymentRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *GetPaymentRequest) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *GetPaymentRequest) GetOrderID() string {
+	if x != nil {
+		return x.OrderID
+	}
+	return ""
+}
+
+type GetPaymentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payment       *Payment               `protobuf:"bytes,1,opt,name=Payment,proto3" json:"Payment,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetPaymentResponse) Reset() {
+	*x = GetPaymentResponse{}
+	mi := &file_api_oms_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetPaymentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetPaymentResponse) ProtoMessage() {}
+
+func (x *GetPaymentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetPaymentResponse.ProtoReflect.Descriptor instead.
+func (*GetPaymentResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *GetPaymentResponse) GetPayment() *Payment {
+	if x != nil {
+		return x.Payment
+	}
+	return nil
+}
+
+// KitchenEvent is published on kitchen.accepted, kitchen.preparing,
+// kitchen.ready and kitchen.failed as an order moves through the kitchen.
+type KitchenEvent struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	OrderID string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	// Station and TicketID identify the ticket an event is about. They are
+	// empty for events about the order as a whole.
+	Station string                 `protobuf:"bytes,2,opt,name=Station,proto3" json:"Station,omitempty"`
+	At      *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=At,proto3" json:"At,omitempty"`
+	// Reason explains a kitchen.failed event.
+	Reason   string `protobuf:"bytes,4,opt,name=Reason,proto3" json:"Reason,omitempty"`
+	TicketID string `protobuf:"bytes,5,opt,name=TicketID,proto3" json:"TicketID,omitempty"`
+	// EstimatedReadyAt is set on kitchen.accepted.
+	EstimatedReadyAt *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=EstimatedReadyAt,proto3" json:"EstimatedReadyAt,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *KitchenEvent) Reset() {
+	*x = KitchenEvent{}
+	mi := &file_api_oms_proto_msgTypes[60]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BookItemsRequest) String() string {
+func (x *KitchenEvent) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BookItemsRequest) ProtoMessage() {}
+func (*KitchenEvent) ProtoMessage() {}
 
-func (x *BookItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[14]
+func (x *KitchenEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[60]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -939,92 +3813,91 @@ func (x *BookItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BookItemsRequest.ProtoReflect.Descriptor instead.
-func (*BookItemsRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{14}
+// Deprecated: Use KitchenEvent.ProtoReflect.Descriptor instead.
+func (*KitchenEvent) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{60}
 }
 
-func (x *BookItemsRequest) GetOrderID() string {
+func (x *KitchenEvent) GetOrderID() string {
 	if x != nil {
 		return x.OrderID
 	}
 	return ""
 }
 
-func (x *BookItemsRequest) GetItems() []*ItemWithQuantity {
+func (x *KitchenEvent) GetStation() string {
 	if x != nil {
-		return x.Items
+		return x.Station
 	}
-	return nil
-}
-
-type BookItemsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Bookings      []*ItemWithQuantity    `protobuf:"bytes,1,rep,name=Bookings,proto3" json:"Bookings,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *BookItemsResponse) Reset() {
-	*x = BookItemsResponse{}
-	mi := &file_api_oms_proto_msgTypes[15]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+	return ""
 }
 
-func (x *BookItemsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *KitchenEvent) GetAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.At
+	}
+	return nil
 }
 
-func (*BookItemsResponse) ProtoMessage() {}
-
-func (x *BookItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[15]
+func (x *KitchenEvent) GetReason() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.Reason
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use BookItemsResponse.ProtoReflect.Descriptor instead.
-func (*BookItemsResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{15}
+func (x *KitchenEvent) GetTicketID() string {
+	if x != nil {
+		return x.TicketID
+	}
+	return ""
 }
 
-func (x *BookItemsResponse) GetBookings() []*ItemWithQuantity {
+func (x *KitchenEvent) GetEstimatedReadyAt() *timestamppb.Timestamp {
 	if x != nil {
-		return x.Bookings
+		return x.EstimatedReadyAt
 	}
 	return nil
 }
 
-type ReleaseBookedItemsRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
-	Items         []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=Items,proto3" json:"Items,omitempty"`
+type Ticket struct {
+	state      protoimpl.MessageState `protogen:"open.v1"`
+	ID         string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	OrderID    string                 `protobuf:"bytes,2,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	Station    string                 `protobuf:"bytes,3,opt,name=Station,proto3" json:"Station,omitempty"`
+	Status     TicketStatus           `protobuf:"varint,4,opt,name=Status,proto3,enum=api.TicketStatus" json:"Status,omitempty"`
+	Items      []*Item                `protobuf:"bytes,5,rep,name=Items,proto3" json:"Items,omitempty"`
+	CreatedAt  *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=CreatedAt,proto3" json:"CreatedAt,omitempty"`
+	StartedAt  *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=StartedAt,proto3" json:"StartedAt,omitempty"`
+	BumpedAt   *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=BumpedAt,proto3" json:"BumpedAt,omitempty"`
+	VoidReason string                 `protobuf:"bytes,9,opt,name=VoidReason,proto3" json:"VoidReason,omitempty"`
+	VIP        bool                   `protobuf:"varint,10,opt,name=VIP,proto3" json:"VIP,omitempty"`
+	Rush       bool                   `protobuf:"varint,11,opt,name=Rush,proto3" json:"Rush,omitempty"`
+	// PrepTime is how long the ticket was estimated to take when it was
+	// queued.
+	PrepTime *durationpb.Duration `protobuf:"bytes,12,opt,name=PrepTime,proto3" json:"PrepTime,omitempty"`
+	// FireAt is when the ticket should be started to be ready together with
+	// the other tickets of its order.
+	FireAt        *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=FireAt,proto3" json:"FireAt,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ReleaseBookedItemsRequest) Reset() {
-	*x = ReleaseBookedItemsRequest{}
-	mi := &file_api_oms_proto_msgTypes[16]
+func (x *Ticket) Reset() {
+	*x = Ticket{}
+	mi := &file_api_oms_proto_msgTypes[61]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ReleaseBookedItemsRequest) String() string {
+func (x *Ticket) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ReleaseBookedItemsRequest) ProtoMessage() {}
+func (*Ticket) ProtoMessage() {}
 
-func (x *ReleaseBookedItemsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[16]
+func (x *Ticket) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[61]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1035,91 +3908,124 @@ func (x *ReleaseBookedItemsRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ReleaseBookedItemsRequest.ProtoReflect.Descriptor instead.
-func (*ReleaseBookedItemsRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{16}
+// Deprecated: Use Ticket.ProtoReflect.Descriptor instead.
+func (*Ticket) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{61}
 }
 
-func (x *ReleaseBookedItemsRequest) GetOrderID() string {
+func (x *Ticket) GetID() string {
+	if x != nil {
+		return x.ID
+	}
+	return ""
+}
+
+func (x *Ticket) GetOrderID() string {
 	if x != nil {
 		return x.OrderID
 	}
 	return ""
 }
 
-func (x *ReleaseBookedItemsRequest) GetItems() []*ItemWithQuantity {
+func (x *Ticket) GetStation() string {
+	if x != nil {
+		return x.Station
+	}
+	return ""
+}
+
+func (x *Ticket) GetStatus() TicketStatus {
+	if x != nil {
+		return x.Status
+	}
+	return TicketStatus_TICKET_STATUS_UNKNOWN
+}
+
+func (x *Ticket) GetItems() []*Item {
 	if x != nil {
 		return x.Items
 	}
 	return nil
 }
 
-type ReleaseBookedItemsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *Ticket) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
 }
 
-func (x *ReleaseBookedItemsResponse) Reset() {
-	*x = ReleaseBookedItemsResponse{}
-	mi := &file_api_oms_proto_msgTypes[17]
-	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-	ms.StoreMessageInfo(mi)
+func (x *Ticket) GetStartedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.StartedAt
+	}
+	return nil
 }
 
-func (x *ReleaseBookedItemsResponse) String() string {
-	return protoimpl.X.MessageStringOf(x)
+func (x *Ticket) GetBumpedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.BumpedAt
+	}
+	return nil
 }
 
-func (*ReleaseBookedItemsResponse) ProtoMessage() {}
-
-func (x *ReleaseBookedItemsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[17]
+func (x *Ticket) GetVoidReason() string {
 	if x != nil {
-		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
-		if ms.LoadMessageInfo() == nil {
-			ms.StoreMessageInfo(mi)
-		}
-		return ms
+		return x.VoidReason
 	}
-	return mi.MessageOf(x)
+	return ""
 }
 
-// Deprecated: Use ReleaseBookedItemsResponse.ProtoReflect.Descriptor instead.
-func (*ReleaseBookedItemsResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{17}
+func (x *Ticket) GetVIP() bool {
+	if x != nil {
+		return x.VIP
+	}
+	return false
 }
 
-func (x *ReleaseBookedItemsResponse) GetSuccess() bool {
+func (x *Ticket) GetRush() bool {
 	if x != nil {
-		return x.Success
+		return x.Rush
 	}
 	return false
 }
 
-type VerifyStockRequest struct {
+func (x *Ticket) GetPrepTime() *durationpb.Duration {
+	if x != nil {
+		return x.PrepTime
+	}
+	return nil
+}
+
+func (x *Ticket) GetFireAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.FireAt
+	}
+	return nil
+}
+
+type ListTicketsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Items         []*ItemWithQuantity    `protobuf:"bytes,1,rep,name=Items,proto3" json:"Items,omitempty"`
+	Station       string                 `protobuf:"bytes,1,opt,name=Station,proto3" json:"Station,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyStockRequest) Reset() {
-	*x = VerifyStockRequest{}
-	mi := &file_api_oms_proto_msgTypes[18]
+func (x *ListTicketsRequest) Reset() {
+	*x = ListTicketsRequest{}
+	mi := &file_api_oms_proto_msgTypes[62]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyStockRequest) String() string {
+func (x *ListTicketsRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyStockRequest) ProtoMessage() {}
+func (*ListTicketsRequest) ProtoMessage() {}
 
-func (x *VerifyStockRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[18]
+func (x *ListTicketsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[62]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1130,41 +4036,43 @@ func (x *VerifyStockRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyStockRequest.ProtoReflect.Descriptor instead.
-func (*VerifyStockRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use ListTicketsRequest.ProtoReflect.Descriptor instead.
+func (*ListTicketsRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{62}
 }
 
-func (x *VerifyStockRequest) GetItems() []*ItemWithQuantity {
+func (x *ListTicketsRequest) GetStation() string {
 	if x != nil {
-		return x.Items
+		return x.Station
 	}
-	return nil
+	return ""
 }
 
-type VerifyStockResponse struct {
-	state                 protoimpl.MessageState `protogen:"open.v1"`
-	AllAvailable          bool                   `protobuf:"varint,1,opt,name=all_available,json=allAvailable,proto3" json:"all_available,omitempty"`
-	MissingOrInsufficient []*ItemWithQuantity    `protobuf:"bytes,2,rep,name=missing_or_insufficient,json=missingOrInsufficient,proto3" json:"missing_or_insufficient,omitempty"`
-	unknownFields         protoimpl.UnknownFields
-	sizeCache             protoimpl.SizeCache
+type ListTicketsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Tickets holds the station's tickets in progress, oldest first, followed
+	// by its queued tickets in the order the kitchen's scheduler wants them
+	// started.
+	Tickets       []*Ticket `protobuf:"bytes,1,rep,name=Tickets,proto3" json:"Tickets,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *VerifyStockResponse) Reset() {
-	*x = VerifyStockResponse{}
-	mi := &file_api_oms_proto_msgTypes[19]
+func (x *ListTicketsResponse) Reset() {
+	*x = ListTicketsResponse{}
+	mi := &file_api_oms_proto_msgTypes[63]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *VerifyStockResponse) String() string {
+func (x *ListTicketsResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*VerifyStockResponse) ProtoMessage() {}
+func (*ListTicketsResponse) ProtoMessage() {}
 
-func (x *VerifyStockResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[19]
+func (x *ListTicketsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[63]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1175,47 +4083,40 @@ func (x *VerifyStockResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use VerifyStockResponse.ProtoReflect.Descriptor instead.
-func (*VerifyStockResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{19}
-}
-
-func (x *VerifyStockResponse) GetAllAvailable() bool {
-	if x != nil {
-		return x.AllAvailable
-	}
-	return false
+// Deprecated: Use ListTicketsResponse.ProtoReflect.Descriptor instead.
+func (*ListTicketsResponse) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{63}
 }
 
-func (x *VerifyStockResponse) GetMissingOrInsufficient() []*ItemWithQuantity {
+func (x *ListTicketsResponse) GetTickets() []*Ticket {
 	if x != nil {
-		return x.MissingOrInsufficient
+		return x.Tickets
 	}
 	return nil
 }
 
-type GetStockItemRequest struct {
+type StartTicketRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	ID            string                 `protobuf:"bytes,1,opt,name=ID,proto3" json:"ID,omitempty"`
+	TicketID      string                 `protobuf:"bytes,1,opt,name=TicketID,proto3" json:"TicketID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStockItemRequest) Reset() {
-	*x = GetStockItemRequest{}
-	mi := &file_api_oms_proto_msgTypes[20]
+func (x *StartTicketRequest) Reset() {
+	*x = StartTicketRequest{}
+	mi := &file_api_oms_proto_msgTypes[64]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStockItemRequest) String() string {
+func (x *StartTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStockItemRequest) ProtoMessage() {}
+func (*StartTicketRequest) ProtoMessage() {}
 
-func (x *GetStockItemRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[20]
+func (x *StartTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[64]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1226,40 +4127,40 @@ func (x *GetStockItemRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStockItemRequest.ProtoReflect.Descriptor instead.
-func (*GetStockItemRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{20}
+// Deprecated: Use StartTicketRequest.ProtoReflect.Descriptor instead.
+func (*StartTicketRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{64}
 }
 
-func (x *GetStockItemRequest) GetID() string {
+func (x *StartTicketRequest) GetTicketID() string {
 	if x != nil {
-		return x.ID
+		return x.TicketID
 	}
 	return ""
 }
 
-type GetStockItemResponse struct {
+type BumpTicketRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Item          *StockItem             `protobuf:"bytes,1,opt,name=Item,proto3" json:"Item,omitempty"`
+	TicketID      string                 `protobuf:"bytes,1,opt,name=TicketID,proto3" json:"TicketID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetStockItemResponse) Reset() {
-	*x = GetStockItemResponse{}
-	mi := &file_api_oms_proto_msgTypes[21]
+func (x *BumpTicketRequest) Reset() {
+	*x = BumpTicketRequest{}
+	mi := &file_api_oms_proto_msgTypes[65]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetStockItemResponse) String() string {
+func (x *BumpTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetStockItemResponse) ProtoMessage() {}
+func (*BumpTicketRequest) ProtoMessage() {}
 
-func (x *GetStockItemResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[21]
+func (x *BumpTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[65]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1270,40 +4171,40 @@ func (x *GetStockItemResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetStockItemResponse.ProtoReflect.Descriptor instead.
-func (*GetStockItemResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use BumpTicketRequest.ProtoReflect.Descriptor instead.
+func (*BumpTicketRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{65}
 }
 
-func (x *GetStockItemResponse) GetItem() *StockItem {
+func (x *BumpTicketRequest) GetTicketID() string {
 	if x != nil {
-		return x.Item
+		return x.TicketID
 	}
-	return nil
+	return ""
 }
 
-type FinalizeBookingRequest struct {
+type RecallTicketRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	OrderID       string                 `protobuf:"bytes,1,opt,name=OrderID,proto3" json:"OrderID,omitempty"`
+	TicketID      string                 `protobuf:"bytes,1,opt,name=TicketID,proto3" json:"TicketID,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FinalizeBookingRequest) Reset() {
-	*x = FinalizeBookingRequest{}
-	mi := &file_api_oms_proto_msgTypes[22]
+func (x *RecallTicketRequest) Reset() {
+	*x = RecallTicketRequest{}
+	mi := &file_api_oms_proto_msgTypes[66]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FinalizeBookingRequest) String() string {
+func (x *RecallTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeBookingRequest) ProtoMessage() {}
+func (*RecallTicketRequest) ProtoMessage() {}
 
-func (x *FinalizeBookingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[22]
+func (x *RecallTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[66]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1314,40 +4215,41 @@ func (x *FinalizeBookingRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeBookingRequest.ProtoReflect.Descriptor instead.
-func (*FinalizeBookingRequest) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use RecallTicketRequest.ProtoReflect.Descriptor instead.
+func (*RecallTicketRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{66}
 }
 
-func (x *FinalizeBookingRequest) GetOrderID() string {
+func (x *RecallTicketRequest) GetTicketID() string {
 	if x != nil {
-		return x.OrderID
+		return x.TicketID
 	}
 	return ""
 }
 
-type FinalizeBookingResponse struct {
+type VoidTicketRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Success       bool                   `protobuf:"varint,1,opt,name=success,proto3" json:"success,omitempty"`
+	TicketID      string                 `protobuf:"bytes,1,opt,name=TicketID,proto3" json:"TicketID,omitempty"`
+	Reason        string                 `protobuf:"bytes,2,opt,name=Reason,proto3" json:"Reason,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *FinalizeBookingResponse) Reset() {
-	*x = FinalizeBookingResponse{}
-	mi := &file_api_oms_proto_msgTypes[23]
+func (x *VoidTicketRequest) Reset() {
+	*x = VoidTicketRequest{}
+	mi := &file_api_oms_proto_msgTypes[67]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *FinalizeBookingResponse) String() string {
+func (x *VoidTicketRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*FinalizeBookingResponse) ProtoMessage() {}
+func (*VoidTicketRequest) ProtoMessage() {}
 
-func (x *FinalizeBookingResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_api_oms_proto_msgTypes[23]
+func (x *VoidTicketRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_oms_proto_msgTypes[67]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1358,55 +4260,107 @@ func (x *FinalizeBookingResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use FinalizeBookingResponse.ProtoReflect.Descriptor instead.
-func (*FinalizeBookingResponse) Descriptor() ([]byte, []int) {
-	return file_api_oms_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use VoidTicketRequest.ProtoReflect.Descriptor instead.
+func (*VoidTicketRequest) Descriptor() ([]byte, []int) {
+	return file_api_oms_proto_rawDescGZIP(), []int{67}
 }
 
-func (x *FinalizeBookingResponse) GetSuccess() bool {
+func (x *VoidTicketRequest) GetTicketID() string {
 	if x != nil {
-		return x.Success
+		return x.TicketID
 	}
-	return false
+	return ""
+}
+
+func (x *VoidTicketRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
 }
 
 var File_api_oms_proto protoreflect.FileDescriptor
 
 const file_api_oms_proto_rawDesc = "" +
 	"\n" +
-	"\rapi/oms.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\"p\n" +
+	"\rapi/oms.proto\x12\x03api\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x1egoogle/protobuf/duration.proto\";\n" +
+	"\x05Money\x12\x1a\n" +
+	"\bCurrency\x18\x01 \x01(\tR\bCurrency\x12\x16\n" +
+	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\"\xa8\x03\n" +
 	"\x05Order\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1e\n" +
 	"\n" +
 	"customerID\x18\x02 \x01(\tR\n" +
 	"customerID\x12\x16\n" +
 	"\x06Status\x18\x03 \x01(\tR\x06Status\x12\x1f\n" +
-	"\x05Items\x18\x04 \x03(\v2\t.api.ItemR\x05Items\"`\n" +
+	"\x05Items\x18\x04 \x03(\v2\t.api.ItemR\x05Items\x128\n" +
+	"\tCreatedAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x12&\n" +
+	"\bSubtotal\x18\x06 \x01(\v2\n" +
+	".api.MoneyR\bSubtotal\x12&\n" +
+	"\bDiscount\x18\a \x01(\v2\n" +
+	".api.MoneyR\bDiscount\x12\x1c\n" +
+	"\x03Tax\x18\b \x01(\v2\n" +
+	".api.MoneyR\x03Tax\x12 \n" +
+	"\x05Total\x18\t \x01(\v2\n" +
+	".api.MoneyR\x05Total\x12F\n" +
+	"\x10EstimatedReadyAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\x10EstimatedReadyAt\x12\x10\n" +
+	"\x03VIP\x18\v \x01(\bR\x03VIP\x12\x12\n" +
+	"\x04Rush\x18\f \x01(\bR\x04Rush\"\xa4\x01\n" +
 	"\x04Item\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1a\n" +
 	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\x12\x12\n" +
 	"\x04Name\x18\x03 \x01(\tR\x04Name\x12\x18\n" +
-	"\aPriceID\x18\x04 \x01(\tR\aPriceID\">\n" +
+	"\aPriceID\x18\x04 \x01(\tR\aPriceID\x12(\n" +
+	"\tUnitPrice\x18\x05 \x01(\v2\n" +
+	".api.MoneyR\tUnitPrice\x12\x18\n" +
+	"\aStation\x18\x06 \x01(\tR\aStation\">\n" +
 	"\x10ItemWithQuantity\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1a\n" +
-	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\"a\n" +
+	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\"\xcd\x01\n" +
 	"\x12CreateOrderRequest\x12\x1e\n" +
 	"\n" +
 	"customerID\x18\x01 \x01(\tR\n" +
 	"customerID\x12+\n" +
-	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"!\n" +
+	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\x12\x1c\n" +
+	"\tPromoCode\x18\x03 \x01(\tR\tPromoCode\x12&\n" +
+	"\x0eIdempotencyKey\x18\x04 \x01(\tR\x0eIdempotencyKey\x12\x10\n" +
+	"\x03VIP\x18\x05 \x01(\bR\x03VIP\x12\x12\n" +
+	"\x04Rush\x18\x06 \x01(\bR\x04Rush\"!\n" +
 	"\x0fGetOrderRequest\x12\x0e\n" +
-	"\x02ID\x18\x01 \x01(\tR\x02ID\"6\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\"\xa0\x02\n" +
 	"\x14GetUserOrdersRequest\x12\x1e\n" +
 	"\n" +
 	"customerID\x18\x01 \x01(\tR\n" +
-	"customerID\";\n" +
+	"customerID\x12\x1a\n" +
+	"\bPageSize\x18\x02 \x01(\x05R\bPageSize\x12\x1c\n" +
+	"\tPageToken\x18\x03 \x01(\tR\tPageToken\x12,\n" +
+	"\bStatuses\x18\x04 \x03(\x0e2\x10.api.OrderStatusR\bStatuses\x12>\n" +
+	"\fCreatedAfter\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\fCreatedAfter\x12@\n" +
+	"\rCreatedBefore\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\rCreatedBefore\"a\n" +
 	"\x15GetUserOrdersResponse\x12\"\n" +
 	"\x06Orders\x18\x01 \x03(\v2\n" +
-	".api.OrderR\x06Orders\"]\n" +
+	".api.OrderR\x06Orders\x12$\n" +
+	"\rNextPageToken\x18\x02 \x01(\tR\rNextPageToken\"\x97\x01\n" +
 	"\x17PatchOrderStatusRequest\x12\x18\n" +
 	"\aorderID\x18\x01 \x01(\tR\aorderID\x12(\n" +
-	"\x06status\x18\x02 \x01(\x0e2\x10.api.OrderStatusR\x06status\"\x95\x02\n" +
+	"\x06status\x18\x02 \x01(\x0e2\x10.api.OrderStatusR\x06status\x12 \n" +
+	"\vtriggeredBy\x18\x03 \x01(\tR\vtriggeredBy\x12\x16\n" +
+	"\x06reason\x18\x04 \x01(\tR\x06reason\"S\n" +
+	"\x11WatchOrderRequest\x12\x18\n" +
+	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12$\n" +
+	"\rAfterSequence\x18\x02 \x01(\x03R\rAfterSequence\"\xf6\x01\n" +
+	"\x10OrderStatusEvent\x12\x1a\n" +
+	"\bSequence\x18\x01 \x01(\x03R\bSequence\x12\x18\n" +
+	"\aOrderID\x18\x02 \x01(\tR\aOrderID\x12$\n" +
+	"\x04From\x18\x03 \x01(\x0e2\x10.api.OrderStatusR\x04From\x12 \n" +
+	"\x02To\x18\x04 \x01(\x0e2\x10.api.OrderStatusR\x02To\x12 \n" +
+	"\vTriggeredBy\x18\x05 \x01(\tR\vTriggeredBy\x12\x16\n" +
+	"\x06Reason\x18\x06 \x01(\tR\x06Reason\x12*\n" +
+	"\x02At\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\x02At\"F\n" +
+	"\x12CancelOrderRequest\x12\x18\n" +
+	"\aorderID\x18\x01 \x01(\tR\aorderID\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"\xef\x02\n" +
 	"\tStockItem\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1a\n" +
 	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\x12\x12\n" +
@@ -1415,7 +4369,12 @@ const file_api_oms_proto_rawDesc = "" +
 	"\vDescription\x18\x05 \x01(\tR\vDescription\x12\x18\n" +
 	"\aImgPath\x18\x06 \x01(\tR\aImgPath\x128\n" +
 	"\tCreatedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
-	"\tUpdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"\xec\x01\n" +
+	"\tUpdatedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\x12\x1c\n" +
+	"\tAvailable\x18\t \x01(\x05R\tAvailable\x12 \n" +
+	"\x05Price\x18\n" +
+	" \x01(\v2\n" +
+	".api.MoneyR\x05Price\x12\x18\n" +
+	"\aStation\x18\v \x01(\tR\aStation\"\xec\x01\n" +
 	"\n" +
 	"BookedItem\x12\x1c\n" +
 	"\tBookingID\x18\x01 \x01(\tR\tBookingID\x12\x16\n" +
@@ -1423,49 +4382,221 @@ const file_api_oms_proto_rawDesc = "" +
 	"\bQuantity\x18\x03 \x01(\x05R\bQuantity\x12\x18\n" +
 	"\aOrderID\x18\x04 \x01(\tR\aOrderID\x128\n" +
 	"\tExpiresAt\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tExpiresAt\x128\n" +
-	"\tCreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\"\xab\x01\n" +
+	"\tCreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\"\xe7\x01\n" +
 	"\x13AddStockItemRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1a\n" +
 	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\x12\x12\n" +
 	"\x04Name\x18\x03 \x01(\tR\x04Name\x12\x18\n" +
 	"\aPriceID\x18\x04 \x01(\tR\aPriceID\x12 \n" +
 	"\vDescription\x18\x05 \x01(\tR\vDescription\x12\x18\n" +
-	"\aImgPath\x18\x06 \x01(\tR\aImgPath\":\n" +
+	"\aImgPath\x18\x06 \x01(\tR\aImgPath\x12 \n" +
+	"\x05Price\x18\a \x01(\v2\n" +
+	".api.MoneyR\x05Price\x12\x18\n" +
+	"\aStation\x18\b \x01(\tR\aStation\":\n" +
 	"\x14AddStockItemResponse\x12\"\n" +
 	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"D\n" +
 	"\x16RemoveStockItemRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x1a\n" +
 	"\bQuantity\x18\x02 \x01(\x05R\bQuantity\"=\n" +
 	"\x17RemoveStockItemResponse\x12\"\n" +
-	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"Y\n" +
+	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"\x86\x01\n" +
 	"\x10BookItemsRequest\x12\x18\n" +
 	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12+\n" +
-	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"F\n" +
+	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\x12+\n" +
+	"\x03TTL\x18\x03 \x01(\v2\x19.google.protobuf.DurationR\x03TTL\"F\n" +
 	"\x11BookItemsResponse\x121\n" +
 	"\bBookings\x18\x01 \x03(\v2\x15.api.ItemWithQuantityR\bBookings\"b\n" +
 	"\x19ReleaseBookedItemsRequest\x12\x18\n" +
 	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12+\n" +
 	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"6\n" +
 	"\x1aReleaseBookedItemsResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess\"A\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"\x96\x01\n" +
+	"\x13BookingExpiredEvent\x12\x18\n" +
+	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12+\n" +
+	"\x05Items\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\x128\n" +
+	"\tExpiredAt\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tExpiredAt\"A\n" +
 	"\x12VerifyStockRequest\x12+\n" +
-	"\x05Items\x18\x01 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"\x89\x01\n" +
+	"\x05Items\x18\x01 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"\xbb\x01\n" +
 	"\x13VerifyStockResponse\x12#\n" +
 	"\rall_available\x18\x01 \x01(\bR\fallAvailable\x12M\n" +
-	"\x17missing_or_insufficient\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x15missingOrInsufficient\"%\n" +
+	"\x17missing_or_insufficient\x18\x02 \x03(\v2\x15.api.ItemWithQuantityR\x15missingOrInsufficient\x120\n" +
+	"\tshortages\x18\x03 \x03(\v2\x12.api.StockShortageR\tshortages\"c\n" +
+	"\rStockShortage\x12\x16\n" +
+	"\x06ItemID\x18\x01 \x01(\tR\x06ItemID\x12\x1c\n" +
+	"\tRequested\x18\x02 \x01(\x05R\tRequested\x12\x1c\n" +
+	"\tAvailable\x18\x03 \x01(\x05R\tAvailable\"%\n" +
 	"\x13GetStockItemRequest\x12\x0e\n" +
 	"\x02ID\x18\x01 \x01(\tR\x02ID\":\n" +
 	"\x14GetStockItemResponse\x12\"\n" +
-	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"2\n" +
+	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"(\n" +
+	"\x14GetStockItemsRequest\x12\x10\n" +
+	"\x03IDs\x18\x01 \x03(\tR\x03IDs\"=\n" +
+	"\x15GetStockItemsResponse\x12$\n" +
+	"\x05Items\x18\x01 \x03(\v2\x0e.api.StockItemR\x05Items\"2\n" +
 	"\x16FinalizeBookingRequest\x12\x18\n" +
 	"\aOrderID\x18\x01 \x01(\tR\aOrderID\"3\n" +
 	"\x17FinalizeBookingResponse\x12\x18\n" +
-	"\asuccess\x18\x01 \x01(\bR\asuccess*D\n" +
+	"\asuccess\x18\x01 \x01(\bR\asuccess\"7\n" +
+	"\x1bReturnFinalizedItemsRequest\x12\x18\n" +
+	"\aOrderID\x18\x01 \x01(\tR\aOrderID\"K\n" +
+	"\x1cReturnFinalizedItemsResponse\x12+\n" +
+	"\x05Items\x18\x01 \x03(\v2\x15.api.ItemWithQuantityR\x05Items\"\xc0\x01\n" +
+	"\x15ListStockItemsRequest\x12\x1a\n" +
+	"\bPageSize\x18\x01 \x01(\x05R\bPageSize\x12\x1c\n" +
+	"\tPageToken\x18\x02 \x01(\tR\tPageToken\x12\x1c\n" +
+	"\tNameQuery\x18\x03 \x01(\tR\tNameQuery\x12 \n" +
+	"\vInStockOnly\x18\x04 \x01(\bR\vInStockOnly\x12-\n" +
+	"\aOrderBy\x18\x05 \x01(\x0e2\x13.api.StockItemOrderR\aOrderBy\"d\n" +
+	"\x16ListStockItemsResponse\x12$\n" +
+	"\x05Items\x18\x01 \x03(\v2\x0e.api.StockItemR\x05Items\x12$\n" +
+	"\rNextPageToken\x18\x02 \x01(\tR\rNextPageToken\"B\n" +
+	"\x1aAdjustStockQuantityRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x14\n" +
+	"\x05Delta\x18\x02 \x01(\x05R\x05Delta\"A\n" +
+	"\x1bAdjustStockQuantityResponse\x12\"\n" +
+	"\x04Item\x18\x01 \x01(\v2\x0e.api.StockItemR\x04Item\"\xce\x02\n" +
+	"\tPriceList\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x12\n" +
+	"\x04Name\x18\x02 \x01(\tR\x04Name\x12\x1a\n" +
+	"\bCurrency\x18\x03 \x01(\tR\bCurrency\x12\x1a\n" +
+	"\bPriority\x18\x04 \x01(\x05R\bPriority\x12@\n" +
+	"\rEffectiveFrom\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\rEffectiveFrom\x12<\n" +
+	"\vEffectiveTo\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vEffectiveTo\x12\x1c\n" +
+	"\tDailyFrom\x18\a \x01(\tR\tDailyFrom\x12\x18\n" +
+	"\aDailyTo\x18\b \x01(\tR\aDailyTo\x12-\n" +
+	"\aEntries\x18\t \x03(\v2\x13.api.PriceListEntryR\aEntries\"B\n" +
+	"\x0ePriceListEntry\x12\x18\n" +
+	"\aPriceID\x18\x01 \x01(\tR\aPriceID\x12\x16\n" +
+	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\"F\n" +
+	"\x16CreatePriceListRequest\x12,\n" +
+	"\tPriceList\x18\x01 \x01(\v2\x0e.api.PriceListR\tPriceList\"c\n" +
+	"\x10SetPricesRequest\x12 \n" +
+	"\vPriceListID\x18\x01 \x01(\tR\vPriceListID\x12-\n" +
+	"\aEntries\x18\x02 \x03(\v2\x13.api.PriceListEntryR\aEntries\"\x17\n" +
+	"\x15ListPriceListsRequest\"H\n" +
+	"\x16ListPriceListsResponse\x12.\n" +
+	"\n" +
+	"PriceLists\x18\x01 \x03(\v2\x0e.api.PriceListR\n" +
+	"PriceLists\"(\n" +
+	"\x16DeletePriceListRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\"\x19\n" +
+	"\x17DeletePriceListResponse\"z\n" +
+	"\x14ResolvePricesRequest\x12\x1a\n" +
+	"\bPriceIDs\x18\x01 \x03(\tR\bPriceIDs\x12*\n" +
+	"\x02At\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\x02At\x12\x1a\n" +
+	"\bCurrency\x18\x03 \x01(\tR\bCurrency\"m\n" +
+	"\rResolvedPrice\x12\x18\n" +
+	"\aPriceID\x18\x01 \x01(\tR\aPriceID\x12 \n" +
+	"\x05Price\x18\x02 \x01(\v2\n" +
+	".api.MoneyR\x05Price\x12 \n" +
+	"\vPriceListID\x18\x03 \x01(\tR\vPriceListID\"C\n" +
+	"\x15ResolvePricesResponse\x12*\n" +
+	"\x06Prices\x18\x01 \x03(\v2\x12.api.ResolvedPriceR\x06Prices\"\xf9\x02\n" +
+	"\aPayment\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x18\n" +
+	"\aOrderID\x18\x02 \x01(\tR\aOrderID\x12\x1e\n" +
+	"\n" +
+	"CustomerID\x18\x03 \x01(\tR\n" +
+	"CustomerID\x12\x16\n" +
+	"\x06Amount\x18\x04 \x01(\x03R\x06Amount\x12&\n" +
+	"\x0eRefundedAmount\x18\x05 \x01(\x03R\x0eRefundedAmount\x12\x1a\n" +
+	"\bCurrency\x18\x06 \x01(\tR\bCurrency\x12\x16\n" +
+	"\x06Status\x18\a \x01(\tR\x06Status\x12\x1a\n" +
+	"\bProvider\x18\b \x01(\tR\bProvider\x12 \n" +
+	"\vProviderRef\x18\t \x01(\tR\vProviderRef\x128\n" +
+	"\tCreatedAt\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tUpdatedAt\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\tUpdatedAt\"\x8a\x01\n" +
+	"\x1aCreatePaymentIntentRequest\x12\x18\n" +
+	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12\x1e\n" +
+	"\n" +
+	"CustomerID\x18\x02 \x01(\tR\n" +
+	"CustomerID\x12\x16\n" +
+	"\x06Amount\x18\x03 \x01(\x03R\x06Amount\x12\x1a\n" +
+	"\bCurrency\x18\x04 \x01(\tR\bCurrency\"E\n" +
+	"\x1bCreatePaymentIntentResponse\x12&\n" +
+	"\aPayment\x18\x01 \x01(\v2\f.api.PaymentR\aPayment\"5\n" +
+	"\x15CapturePaymentRequest\x12\x1c\n" +
+	"\tPaymentID\x18\x01 \x01(\tR\tPaymentID\"@\n" +
+	"\x16CapturePaymentResponse\x12&\n" +
+	"\aPayment\x18\x01 \x01(\v2\f.api.PaymentR\aPayment\"L\n" +
+	"\x14RefundPaymentRequest\x12\x1c\n" +
+	"\tPaymentID\x18\x01 \x01(\tR\tPaymentID\x12\x16\n" +
+	"\x06Amount\x18\x02 \x01(\x03R\x06Amount\"?\n" +
+	"\x15RefundPaymentResponse\x12&\n" +
+	"\aPayment\x18\x01 \x01(\v2\f.api.PaymentR\aPayment\"2\n" +
+	"\x12VoidPaymentRequest\x12\x1c\n" +
+	"\tPaymentID\x18\x01 \x01(\tR\tPaymentID\"=\n" +
+	"\x13VoidPaymentResponse\x12&\n" +
+	"\aPayment\x18\x01 \x01(\v2\f.api.PaymentR\aPayment\"=\n" +
+	"\x11GetPaymentRequest\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x18\n" +
+	"\aOrderID\x18\x02 \x01(\tR\aOrderID\"<\n" +
+	"\x12GetPaymentResponse\x12&\n" +
+	"\aPayment\x18\x01 \x01(\v2\f.api.PaymentR\aPayment\"\xea\x01\n" +
+	"\fKitchenEvent\x12\x18\n" +
+	"\aOrderID\x18\x01 \x01(\tR\aOrderID\x12\x18\n" +
+	"\aStation\x18\x02 \x01(\tR\aStation\x12*\n" +
+	"\x02At\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02At\x12\x16\n" +
+	"\x06Reason\x18\x04 \x01(\tR\x06Reason\x12\x1a\n" +
+	"\bTicketID\x18\x05 \x01(\tR\bTicketID\x12F\n" +
+	"\x10EstimatedReadyAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x10EstimatedReadyAt\"\xf5\x03\n" +
+	"\x06Ticket\x12\x0e\n" +
+	"\x02ID\x18\x01 \x01(\tR\x02ID\x12\x18\n" +
+	"\aOrderID\x18\x02 \x01(\tR\aOrderID\x12\x18\n" +
+	"\aStation\x18\x03 \x01(\tR\aStation\x12)\n" +
+	"\x06Status\x18\x04 \x01(\x0e2\x11.api.TicketStatusR\x06Status\x12\x1f\n" +
+	"\x05Items\x18\x05 \x03(\v2\t.api.ItemR\x05Items\x128\n" +
+	"\tCreatedAt\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tCreatedAt\x128\n" +
+	"\tStartedAt\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tStartedAt\x126\n" +
+	"\bBumpedAt\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bBumpedAt\x12\x1e\n" +
+	"\n" +
+	"VoidReason\x18\t \x01(\tR\n" +
+	"VoidReason\x12\x10\n" +
+	"\x03VIP\x18\n" +
+	" \x01(\bR\x03VIP\x12\x12\n" +
+	"\x04Rush\x18\v \x01(\bR\x04Rush\x125\n" +
+	"\bPrepTime\x18\f \x01(\v2\x19.google.protobuf.DurationR\bPrepTime\x122\n" +
+	"\x06FireAt\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\x06FireAt\".\n" +
+	"\x12ListTicketsRequest\x12\x18\n" +
+	"\aStation\x18\x01 \x01(\tR\aStation\"<\n" +
+	"\x13ListTicketsResponse\x12%\n" +
+	"\aTickets\x18\x01 \x03(\v2\v.api.TicketR\aTickets\"0\n" +
+	"\x12StartTicketRequest\x12\x1a\n" +
+	"\bTicketID\x18\x01 \x01(\tR\bTicketID\"/\n" +
+	"\x11BumpTicketRequest\x12\x1a\n" +
+	"\bTicketID\x18\x01 \x01(\tR\bTicketID\"1\n" +
+	"\x13RecallTicketRequest\x12\x1a\n" +
+	"\bTicketID\x18\x01 \x01(\tR\bTicketID\"G\n" +
+	"\x11VoidTicketRequest\x12\x1a\n" +
+	"\bTicketID\x18\x01 \x01(\tR\bTicketID\x12\x16\n" +
+	"\x06Reason\x18\x02 \x01(\tR\x06Reason*\x84\x01\n" +
 	"\vOrderStatus\x12\v\n" +
 	"\aUNKNOWN\x10\x00\x12\v\n" +
 	"\aPENDING\x10\x01\x12\r\n" +
 	"\tCOMPLETED\x10\x02\x12\f\n" +
-	"\bCANCELED\x10\x032\xf6\x01\n" +
+	"\bCANCELED\x10\x03\x12\b\n" +
+	"\x04PAID\x10\x04\x12\f\n" +
+	"\bACCEPTED\x10\x05\x12\r\n" +
+	"\tPREPARING\x10\x06\x12\t\n" +
+	"\x05READY\x10\a\x12\f\n" +
+	"\bREFUNDED\x10\b*U\n" +
+	"\x0eStockItemOrder\x12\x12\n" +
+	"\x0eSTOCK_ORDER_ID\x10\x00\x12\x14\n" +
+	"\x10STOCK_ORDER_NAME\x10\x01\x12\x19\n" +
+	"\x15STOCK_ORDER_AVAILABLE\x10\x02*y\n" +
+	"\rPaymentStatus\x12\x13\n" +
+	"\x0fPAYMENT_UNKNOWN\x10\x00\x12\x13\n" +
+	"\x0fPAYMENT_PENDING\x10\x01\x12\x14\n" +
+	"\x10PAYMENT_CAPTURED\x10\x02\x12\x14\n" +
+	"\x10PAYMENT_REFUNDED\x10\x03\x12\x12\n" +
+	"\x0ePAYMENT_FAILED\x10\x04*z\n" +
+	"\fTicketStatus\x12\x19\n" +
+	"\x15TICKET_STATUS_UNKNOWN\x10\x00\x12\x11\n" +
+	"\rTICKET_QUEUED\x10\x01\x12\x16\n" +
+	"\x12TICKET_IN_PROGRESS\x10\x02\x12\x11\n" +
+	"\rTICKET_BUMPED\x10\x03\x12\x11\n" +
+	"\rTICKET_VOIDED\x10\x042\x9c\x03\n" +
 	"\fOrderService\x122\n" +
 	"\vCreateOrder\x12\x17.api.CreateOrderRequest\x1a\n" +
 	".api.Order\x12,\n" +
@@ -1473,15 +4604,47 @@ const file_api_oms_proto_rawDesc = "" +
 	".api.Order\x12F\n" +
 	"\rGetUserOrders\x12\x19.api.GetUserOrdersRequest\x1a\x1a.api.GetUserOrdersResponse\x12<\n" +
 	"\x10PatchOrderStatus\x12\x1c.api.PatchOrderStatusRequest\x1a\n" +
-	".api.Order2\x89\x04\n" +
+	".api.Order\x122\n" +
+	"\vCancelOrder\x12\x17.api.CancelOrderRequest\x1a\n" +
+	".api.Order\x121\n" +
+	"\n" +
+	"QuoteOrder\x12\x17.api.CreateOrderRequest\x1a\n" +
+	".api.Order\x12=\n" +
+	"\n" +
+	"WatchOrder\x12\x16.api.WatchOrderRequest\x1a\x15.api.OrderStatusEvent0\x012\xd3\x06\n" +
 	"\fStockService\x12C\n" +
 	"\fAddStockItem\x12\x18.api.AddStockItemRequest\x1a\x19.api.AddStockItemResponse\x12:\n" +
 	"\tBookItems\x12\x15.api.BookItemsRequest\x1a\x16.api.BookItemsResponse\x12U\n" +
 	"\x12ReleaseBookedItems\x12\x1e.api.ReleaseBookedItemsRequest\x1a\x1f.api.ReleaseBookedItemsResponse\x12L\n" +
 	"\x0fRemoveStockItem\x12\x1b.api.RemoveStockItemRequest\x1a\x1c.api.RemoveStockItemResponse\x12@\n" +
 	"\vVerifyStock\x12\x17.api.VerifyStockRequest\x1a\x18.api.VerifyStockResponse\x12C\n" +
-	"\fGetStockItem\x12\x18.api.GetStockItemRequest\x1a\x19.api.GetStockItemResponse\x12L\n" +
-	"\x0fFinalizeBooking\x12\x1b.api.FinalizeBookingRequest\x1a\x1c.api.FinalizeBookingResponseB&Z$github.com/kiriyms/oms_go-common/apib\x06proto3"
+	"\fGetStockItem\x12\x18.api.GetStockItemRequest\x1a\x19.api.GetStockItemResponse\x12F\n" +
+	"\rGetStockItems\x12\x19.api.GetStockItemsRequest\x1a\x1a.api.GetStockItemsResponse\x12L\n" +
+	"\x0fFinalizeBooking\x12\x1b.api.FinalizeBookingRequest\x1a\x1c.api.FinalizeBookingResponse\x12[\n" +
+	"\x14ReturnFinalizedItems\x12 .api.ReturnFinalizedItemsRequest\x1a!.api.ReturnFinalizedItemsResponse\x12I\n" +
+	"\x0eListStockItems\x12\x1a.api.ListStockItemsRequest\x1a\x1b.api.ListStockItemsResponse\x12X\n" +
+	"\x13AdjustStockQuantity\x12\x1f.api.AdjustStockQuantityRequest\x1a .api.AdjustStockQuantityResponse2\xe5\x02\n" +
+	"\x0ePricingService\x12>\n" +
+	"\x0fCreatePriceList\x12\x1b.api.CreatePriceListRequest\x1a\x0e.api.PriceList\x122\n" +
+	"\tSetPrices\x12\x15.api.SetPricesRequest\x1a\x0e.api.PriceList\x12I\n" +
+	"\x0eListPriceLists\x12\x1a.api.ListPriceListsRequest\x1a\x1b.api.ListPriceListsResponse\x12L\n" +
+	"\x0fDeletePriceList\x12\x1b.api.DeletePriceListRequest\x1a\x1c.api.DeletePriceListResponse\x12F\n" +
+	"\rResolvePrices\x12\x19.api.ResolvePricesRequest\x1a\x1a.api.ResolvePricesResponse2\xfe\x02\n" +
+	"\x0ePaymentService\x12X\n" +
+	"\x13CreatePaymentIntent\x12\x1f.api.CreatePaymentIntentRequest\x1a .api.CreatePaymentIntentResponse\x12I\n" +
+	"\x0eCapturePayment\x12\x1a.api.CapturePaymentRequest\x1a\x1b.api.CapturePaymentResponse\x12F\n" +
+	"\rRefundPayment\x12\x19.api.RefundPaymentRequest\x1a\x1a.api.RefundPaymentResponse\x12@\n" +
+	"\vVoidPayment\x12\x17.api.VoidPaymentRequest\x1a\x18.api.VoidPaymentResponse\x12=\n" +
+	"\n" +
+	"GetPayment\x12\x16.api.GetPaymentRequest\x1a\x17.api.GetPaymentResponse2\xa4\x02\n" +
+	"\x0eKitchenService\x12@\n" +
+	"\vListTickets\x12\x17.api.ListTicketsRequest\x1a\x18.api.ListTicketsResponse\x123\n" +
+	"\vStartTicket\x12\x17.api.StartTicketRequest\x1a\v.api.Ticket\x121\n" +
+	"\n" +
+	"BumpTicket\x12\x16.api.BumpTicketRequest\x1a\v.api.Ticket\x125\n" +
+	"\fRecallTicket\x12\x18.api.RecallTicketRequest\x1a\v.api.Ticket\x121\n" +
+	"\n" +
+	"VoidTicket\x12\x16.api.VoidTicketRequest\x1a\v.api.TicketB&Z$github.com/kiriyms/oms_go-common/apib\x06proto3"
 
 var (
 	file_api_oms_proto_rawDescOnce sync.Once
@@ -1495,80 +4658,222 @@ func file_api_oms_proto_rawDescGZIP() []byte {
 	return file_api_oms_proto_rawDescData
 }
 
-var file_api_oms_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_api_oms_proto_msgTypes = make([]protoimpl.MessageInfo, 24)
+var file_api_oms_proto_enumTypes = make([]protoimpl.EnumInfo, 4)
+var file_api_oms_proto_msgTypes = make([]protoimpl.MessageInfo, 68)
 var file_api_oms_proto_goTypes = []any{
-	(OrderStatus)(0),                   // 0: api.OrderStatus
-	(*Order)(nil),                      // 1: api.Order
-	(*Item)(nil),                       // 2: api.Item
-	(*ItemWithQuantity)(nil),           // 3: api.ItemWithQuantity
-	(*CreateOrderRequest)(nil),         // 4: api.CreateOrderRequest
-	(*GetOrderRequest)(nil),            // 5: api.GetOrderRequest
-	(*GetUserOrdersRequest)(nil),       // 6: api.GetUserOrdersRequest
-	(*GetUserOrdersResponse)(nil),      // 7: api.GetUserOrdersResponse
-	(*PatchOrderStatusRequest)(nil),    // 8: api.PatchOrderStatusRequest
-	(*StockItem)(nil),                  // 9: api.StockItem
-	(*BookedItem)(nil),                 // 10: api.BookedItem
-	(*AddStockItemRequest)(nil),        // 11: api.AddStockItemRequest
-	(*AddStockItemResponse)(nil),       // 12: api.AddStockItemResponse
-	(*RemoveStockItemRequest)(nil),     // 13: api.RemoveStockItemRequest
-	(*RemoveStockItemResponse)(nil),    // 14: api.RemoveStockItemResponse
-	(*BookItemsRequest)(nil),           // 15: api.BookItemsRequest
-	(*BookItemsResponse)(nil),          // 16: api.BookItemsResponse
-	(*ReleaseBookedItemsRequest)(nil),  // 17: api.ReleaseBookedItemsRequest
-	(*ReleaseBookedItemsResponse)(nil), // 18: api.ReleaseBookedItemsResponse
-	(*VerifyStockRequest)(nil),         // 19: api.VerifyStockRequest
-	(*VerifyStockResponse)(nil),        // 20: api.VerifyStockResponse
-	(*GetStockItemRequest)(nil),        // 21: api.GetStockItemRequest
-	(*GetStockItemResponse)(nil),       // 22: api.GetStockItemResponse
-	(*FinalizeBookingRequest)(nil),     // 23: api.FinalizeBookingRequest
-	(*FinalizeBookingResponse)(nil),    // 24: api.FinalizeBookingResponse
-	(*timestamppb.Timestamp)(nil),      // 25: google.protobuf.Timestamp
+	(OrderStatus)(0),                     // 0: api.OrderStatus
+	(StockItemOrder)(0),                  // 1: api.StockItemOrder
+	(PaymentStatus)(0),                   // 2: api.PaymentStatus
+	(TicketStatus)(0),                    // 3: api.TicketStatus
+	(*Money)(nil),                        // 4: api.Money
+	(*Order)(nil),                        // 5: api.Order
+	(*Item)(nil),                         // 6: api.Item
+	(*ItemWithQuantity)(nil),             // 7: api.ItemWithQuantity
+	(*CreateOrderRequest)(nil),           // 8: api.CreateOrderRequest
+	(*GetOrderRequest)(nil),              // 9: api.GetOrderRequest
+	(*GetUserOrdersRequest)(nil),         // 10: api.GetUserOrdersRequest
+	(*GetUserOrdersResponse)(nil),        // 11: api.GetUserOrdersResponse
+	(*PatchOrderStatusRequest)(nil),      // 12: api.PatchOrderStatusRequest
+	(*WatchOrderRequest)(nil),            // 13: api.WatchOrderRequest
+	(*OrderStatusEvent)(nil),             // 14: api.OrderStatusEvent
+	(*CancelOrderRequest)(nil),           // 15: api.CancelOrderRequest
+	(*StockItem)(nil),                    // 16: api.StockItem
+	(*BookedItem)(nil),                   // 17: api.BookedItem
+	(*AddStockItemRequest)(nil),          // 18: api.AddStockItemRequest
+	(*AddStockItemResponse)(nil),         // 19: api.AddStockItemResponse
+	(*RemoveStockItemRequest)(nil),       // 20: api.RemoveStockItemRequest
+	(*RemoveStockItemResponse)(nil),      // 21: api.RemoveStockItemResponse
+	(*BookItemsRequest)(nil),             // 22: api.BookItemsRequest
+	(*BookItemsResponse)(nil),            // 23: api.BookItemsResponse
+	(*ReleaseBookedItemsRequest)(nil),    // 24: api.ReleaseBookedItemsRequest
+	(*ReleaseBookedItemsResponse)(nil),   // 25: api.ReleaseBookedItemsResponse
+	(*BookingExpiredEvent)(nil),          // 26: api.BookingExpiredEvent
+	(*VerifyStockRequest)(nil),           // 27: api.VerifyStockRequest
+	(*VerifyStockResponse)(nil),          // 28: api.VerifyStockResponse
+	(*StockShortage)(nil),                // 29: api.StockShortage
+	(*GetStockItemRequest)(nil),          // 30: api.GetStockItemRequest
+	(*GetStockItemResponse)(nil),         // 31: api.GetStockItemResponse
+	(*GetStockItemsRequest)(nil),         // 32: api.GetStockItemsRequest
+	(*GetStockItemsResponse)(nil),        // 33: api.GetStockItemsResponse
+	(*FinalizeBookingRequest)(nil),       // 34: api.FinalizeBookingRequest
+	(*FinalizeBookingResponse)(nil),      // 35: api.FinalizeBookingResponse
+	(*ReturnFinalizedItemsRequest)(nil),  // 36: api.ReturnFinalizedItemsRequest
+	(*ReturnFinalizedItemsResponse)(nil), // 37: api.ReturnFinalizedItemsResponse
+	(*ListStockItemsRequest)(nil),        // 38: api.ListStockItemsRequest
+	(*ListStockItemsResponse)(nil),       // 39: api.ListStockItemsResponse
+	(*AdjustStockQuantityRequest)(nil),   // 40: api.AdjustStockQuantityRequest
+	(*AdjustStockQuantityResponse)(nil),  // 41: api.AdjustStockQuantityResponse
+	(*PriceList)(nil),                    // 42: api.PriceList
+	(*PriceListEntry)(nil),               // 43: api.PriceListEntry
+	(*CreatePriceListRequest)(nil),       // 44: api.CreatePriceListRequest
+	(*SetPricesRequest)(nil),             // 45: api.SetPricesRequest
+	(*ListPriceListsRequest)(nil),        // 46: api.ListPriceListsRequest
+	(*ListPriceListsResponse)(nil),       // 47: api.ListPriceListsResponse
+	(*DeletePriceListRequest)(nil),       // 48: api.DeletePriceListRequest
+	(*DeletePriceListResponse)(nil),      // 49: api.DeletePriceListResponse
+	(*ResolvePricesRequest)(nil),         // 50: api.ResolvePricesRequest
+	(*ResolvedPrice)(nil),                // 51: api.ResolvedPrice
+	(*ResolvePricesResponse)(nil),        // 52: api.ResolvePricesResponse
+	(*Payment)(nil),                      // 53: api.Payment
+	(*CreatePaymentIntentRequest)(nil),   // 54: api.CreatePaymentIntentRequest
+	(*CreatePaymentIntentResponse)(nil),  // 55: api.CreatePaymentIntentResponse
+	(*CapturePaymentRequest)(nil),        // 56: api.CapturePaymentRequest
+	(*CapturePaymentResponse)(nil),       // 57: api.CapturePaymentResponse
+	(*RefundPaymentRequest)(nil),         // 58: api.RefundPaymentRequest
+	(*RefundPaymentResponse)(nil),        // 59: api.RefundPaymentResponse
+	(*VoidPaymentRequest)(nil),           // 60: api.VoidPaymentRequest
+	(*VoidPaymentResponse)(nil),          // 61: api.VoidPaymentResponse
+	(*GetPaymentRequest)(nil),            // 62: api.GetPaymentRequest
+	(*GetPaymentResponse)(nil),           // 63: api.GetPaymentResponse
+	(*KitchenEvent)(nil),                 // 64: api.KitchenEvent
+	(*Ticket)(nil),                       // 65: api.Ticket
+	(*ListTicketsRequest)(nil),           // 66: api.ListTicketsRequest
+	(*ListTicketsResponse)(nil),          // 67: api.ListTicketsResponse
+	(*StartTicketRequest)(nil),           // 68: api.StartTicketRequest
+	(*BumpTicketRequest)(nil),            // 69: api.BumpTicketRequest
+	(*RecallTicketRequest)(nil),          // 70: api.RecallTicketRequest
+	(*VoidTicketRequest)(nil),            // 71: api.VoidTicketRequest
+	(*timestamppb.Timestamp)(nil),        // 72: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),          // 73: google.protobuf.Duration
 }
 var file_api_oms_proto_depIdxs = []int32{
-	2,  // 0: api.Order.Items:type_name -> api.Item
-	3,  // 1: api.CreateOrderRequest.Items:type_name -> api.ItemWithQuantity
-	1,  // 2: api.GetUserOrdersResponse.Orders:type_name -> api.Order
-	0,  // 3: api.PatchOrderStatusRequest.status:type_name -> api.OrderStatus
-	25, // 4: api.StockItem.CreatedAt:type_name -> google.protobuf.Timestamp
-	25, // 5: api.StockItem.UpdatedAt:type_name -> google.protobuf.Timestamp
-	25, // 6: api.BookedItem.ExpiresAt:type_name -> google.protobuf.Timestamp
-	25, // 7: api.BookedItem.CreatedAt:type_name -> google.protobuf.Timestamp
-	9,  // 8: api.AddStockItemResponse.Item:type_name -> api.StockItem
-	9,  // 9: api.RemoveStockItemResponse.Item:type_name -> api.StockItem
-	3,  // 10: api.BookItemsRequest.Items:type_name -> api.ItemWithQuantity
-	3,  // 11: api.BookItemsResponse.Bookings:type_name -> api.ItemWithQuantity
-	3,  // 12: api.ReleaseBookedItemsRequest.Items:type_name -> api.ItemWithQuantity
-	3,  // 13: api.VerifyStockRequest.Items:type_name -> api.ItemWithQuantity
-	3,  // 14: api.VerifyStockResponse.missing_or_insufficient:type_name -> api.ItemWithQuantity
-	9,  // 15: api.GetStockItemResponse.Item:type_name -> api.StockItem
-	4,  // 16: api.OrderService.CreateOrder:input_type -> api.CreateOrderRequest
-	5,  // 17: api.OrderService.GetOrder:input_type -> api.GetOrderRequest
-	6,  // 18: api.OrderService.GetUserOrders:input_type -> api.GetUserOrdersRequest
-	8,  // 19: api.OrderService.PatchOrderStatus:input_type -> api.PatchOrderStatusRequest
-	11, // 20: api.StockService.AddStockItem:input_type -> api.AddStockItemRequest
-	15, // 21: api.StockService.BookItems:input_type -> api.BookItemsRequest
-	17, // 22: api.StockService.ReleaseBookedItems:input_type -> api.ReleaseBookedItemsRequest
-	13, // 23: api.StockService.RemoveStockItem:input_type -> api.RemoveStockItemRequest
-	19, // 24: api.StockService.VerifyStock:input_type -> api.VerifyStockRequest
-	21, // 25: api.StockService.GetStockItem:input_type -> api.GetStockItemRequest
-	23, // 26: api.StockService.FinalizeBooking:input_type -> api.FinalizeBookingRequest
-	1,  // 27: api.OrderService.CreateOrder:output_type -> api.Order
-	1,  // 28: api.OrderService.GetOrder:output_type -> api.Order
-	7,  // 29: api.OrderService.GetUserOrders:output_type -> api.GetUserOrdersResponse
-	1,  // 30: api.OrderService.PatchOrderStatus:output_type -> api.Order
-	12, // 31: api.StockService.AddStockItem:output_type -> api.AddStockItemResponse
-	16, // 32: api.StockService.BookItems:output_type -> api.BookItemsResponse
-	18, // 33: api.StockService.ReleaseBookedItems:output_type -> api.ReleaseBookedItemsResponse
-	14, // 34: api.StockService.RemoveStockItem:output_type -> api.RemoveStockItemResponse
-	20, // 35: api.StockService.VerifyStock:output_type -> api.VerifyStockResponse
-	22, // 36: api.StockService.GetStockItem:output_type -> api.GetStockItemResponse
-	24, // 37: api.StockService.FinalizeBooking:output_type -> api.FinalizeBookingResponse
-	27, // [27:38] is the sub-list for method output_type
-	16, // [16:27] is the sub-list for method input_type
-	16, // [16:16] is the sub-list for extension type_name
-	16, // [16:16] is the sub-list for extension extendee
-	0,  // [0:16] is the sub-list for field type_name
+	6,  // 0: api.Order.Items:type_name -> api.Item
+	72, // 1: api.Order.CreatedAt:type_name -> google.protobuf.Timestamp
+	4,  // 2: api.Order.Subtotal:type_name -> api.Money
+	4,  // 3: api.Order.Discount:type_name -> api.Money
+	4,  // 4: api.Order.Tax:type_name -> api.Money
+	4,  // 5: api.Order.Total:type_name -> api.Money
+	72, // 6: api.Order.EstimatedReadyAt:type_name -> google.protobuf.Timestamp
+	4,  // 7: api.Item.UnitPrice:type_name -> api.Money
+	7,  // 8: api.CreateOrderRequest.Items:type_name -> api.ItemWithQuantity
+	0,  // 9: api.GetUserOrdersRequest.Statuses:type_name -> api.OrderStatus
+	72, // 10: api.GetUserOrdersRequest.CreatedAfter:type_name -> google.protobuf.Timestamp
+	72, // 11: api.GetUserOrdersRequest.CreatedBefore:type_name -> google.protobuf.Timestamp
+	5,  // 12: api.GetUserOrdersResponse.Orders:type_name -> api.Order
+	0,  // 13: api.PatchOrderStatusRequest.status:type_name -> api.OrderStatus
+	0,  // 14: api.OrderStatusEvent.From:type_name -> api.OrderStatus
+	0,  // 15: api.OrderStatusEvent.To:type_name -> api.OrderStatus
+	72, // 16: api.OrderStatusEvent.At:type_name -> google.protobuf.Timestamp
+	72, // 17: api.StockItem.CreatedAt:type_name -> google.protobuf.Timestamp
+	72, // 18: api.StockItem.UpdatedAt:type_name -> google.protobuf.Timestamp
+	4,  // 19: api.StockItem.Price:type_name -> api.Money
+	72, // 20: api.BookedItem.ExpiresAt:type_name -> google.protobuf.Timestamp
+	72, // 21: api.BookedItem.CreatedAt:type_name -> google.protobuf.Timestamp
+	4,  // 22: api.AddStockItemRequest.Price:type_name -> api.Money
+	16, // 23: api.AddStockItemResponse.Item:type_name -> api.StockItem
+	16, // 24: api.RemoveStockItemResponse.Item:type_name -> api.StockItem
+	7,  // 25: api.BookItemsRequest.Items:type_name -> api.ItemWithQuantity
+	73, // 26: api.BookItemsRequest.TTL:type_name -> google.protobuf.Duration
+	7,  // 27: api.BookItemsResponse.Bookings:type_name -> api.ItemWithQuantity
+	7,  // 28: api.ReleaseBookedItemsRequest.Items:type_name -> api.ItemWithQuantity
+	7,  // 29: api.BookingExpiredEvent.Items:type_name -> api.ItemWithQuantity
+	72, // 30: api.BookingExpiredEvent.ExpiredAt:type_name -> google.protobuf.Timestamp
+	7,  // 31: api.VerifyStockRequest.Items:type_name -> api.ItemWithQuantity
+	7,  // 32: api.VerifyStockResponse.missing_or_insufficient:type_name -> api.ItemWithQuantity
+	29, // 33: api.VerifyStockResponse.shortages:type_name -> api.StockShortage
+	16, // 34: api.GetStockItemResponse.Item:type_name -> api.StockItem
+	16, // 35: api.GetStockItemsResponse.Items:type_name -> api.StockItem
+	7,  // 36: api.ReturnFinalizedItemsResponse.Items:type_name -> api.ItemWithQuantity
+	1,  // 37: api.ListStockItemsRequest.OrderBy:type_name -> api.StockItemOrder
+	16, // 38: api.ListStockItemsResponse.Items:type_name -> api.StockItem
+	16, // 39: api.AdjustStockQuantityResponse.Item:type_name -> api.StockItem
+	72, // 40: api.PriceList.EffectiveFrom:type_name -> google.protobuf.Timestamp
+	72, // 41: api.PriceList.EffectiveTo:type_name -> google.protobuf.Timestamp
+	43, // 42: api.PriceList.Entries:type_name -> api.PriceListEntry
+	42, // 43: api.CreatePriceListRequest.PriceList:type_name -> api.PriceList
+	43, // 44: api.SetPricesRequest.Entries:type_name -> api.PriceListEntry
+	42, // 45: api.ListPriceListsResponse.PriceLists:type_name -> api.PriceList
+	72, // 46: api.ResolvePricesRequest.At:type_name -> google.protobuf.Timestamp
+	4,  // 47: api.ResolvedPrice.Price:type_name -> api.Money
+	51, // 48: api.ResolvePricesResponse.Prices:type_name -> api.ResolvedPrice
+	72, // 49: api.Payment.CreatedAt:type_name -> google.protobuf.Timestamp
+	72, // 50: api.Payment.UpdatedAt:type_name -> google.protobuf.Timestamp
+	53, // 51: api.CreatePaymentIntentResponse.Payment:type_name -> api.Payment
+	53, // 52: api.CapturePaymentResponse.Payment:type_name -> api.Payment
+	53, // 53: api.RefundPaymentResponse.Payment:type_name -> api.Payment
+	53, // 54: api.VoidPaymentResponse.Payment:type_name -> api.Payment
+	53, // 55: api.GetPaymentResponse.Payment:type_name -> api.Payment
+	72, // 56: api.KitchenEvent.At:type_name -> google.protobuf.Timestamp
+	72, // 57: api.KitchenEvent.EstimatedReadyAt:type_name -> google.protobuf.Timestamp
+	3,  // 58: api.Ticket.Status:type_name -> api.TicketStatus
+	6,  // 59: api.Ticket.Items:type_name -> api.Item
+	72, // 60: api.Ticket.CreatedAt:type_name -> google.protobuf.Timestamp
+	72, // 61: api.Ticket.StartedAt:type_name -> google.protobuf.Timestamp
+	72, // 62: api.Ticket.BumpedAt:type_name -> google.protobuf.Timestamp
+	73, // 63: api.Ticket.PrepTime:type_name -> google.protobuf.Duration
+	72, // 64: api.Ticket.FireAt:type_name -> google.protobuf.Timestamp
+	65, // 65: api.ListTicketsResponse.Tickets:type_name -> api.Ticket
+	8,  // 66: api.OrderService.CreateOrder:input_type -> api.CreateOrderRequest
+	9,  // 67: api.OrderService.GetOrder:input_type -> api.GetOrderRequest
+	10, // 68: api.OrderService.GetUserOrders:input_type -> api.GetUserOrdersRequest
+	12, // 69: api.OrderService.PatchOrderStatus:input_type -> api.PatchOrderStatusRequest
+	15, // 70: api.OrderService.CancelOrder:input_type -> api.CancelOrderRequest
+	8,  // 71: api.OrderService.QuoteOrder:input_type -> api.CreateOrderRequest
+	13, // 72: api.OrderService.WatchOrder:input_type -> api.WatchOrderRequest
+	18, // 73: api.StockService.AddStockItem:input_type -> api.AddStockItemRequest
+	22, // 74: api.StockService.BookItems:input_type -> api.BookItemsRequest
+	24, // 75: api.StockService.ReleaseBookedItems:input_type -> api.ReleaseBookedItemsRequest
+	20, // 76: api.StockService.RemoveStockItem:input_type -> api.RemoveStockItemRequest
+	27, // 77: api.StockService.VerifyStock:input_type -> api.VerifyStockRequest
+	30, // 78: api.StockService.GetStockItem:input_type -> api.GetStockItemRequest
+	32, // 79: api.StockService.GetStockItems:input_type -> api.GetStockItemsRequest
+	34, // 80: api.StockService.FinalizeBooking:input_type -> api.FinalizeBookingRequest
+	36, // 81: api.StockService.ReturnFinalizedItems:input_type -> api.ReturnFinalizedItemsRequest
+	38, // 82: api.StockService.ListStockItems:input_type -> api.ListStockItemsRequest
+	40, // 83: api.StockService.AdjustStockQuantity:input_type -> api.AdjustStockQuantityRequest
+	44, // 84: api.PricingService.CreatePriceList:input_type -> api.CreatePriceListRequest
+	45, // 85: api.PricingService.SetPrices:input_type -> api.SetPricesRequest
+	46, // 86: api.PricingService.ListPriceLists:input_type -> api.ListPriceListsRequest
+	48, // 87: api.PricingService.DeletePriceList:input_type -> api.DeletePriceListRequest
+	50, // 88: api.PricingService.ResolvePrices:input_type -> api.ResolvePricesRequest
+	54, // 89: api.PaymentService.CreatePaymentIntent:input_type -> api.CreatePaymentIntentRequest
+	56, // 90: api.PaymentService.CapturePayment:input_type -> api.CapturePaymentRequest
+	58, // 91: api.PaymentService.RefundPayment:input_type -> api.RefundPaymentRequest
+	60, // 92: api.PaymentService.VoidPayment:input_type -> api.VoidPaymentRequest
+	62, // 93: api.PaymentService.GetPayment:input_type -> api.GetPaymentRequest
+	66, // 94: api.KitchenService.ListTickets:input_type -> api.ListTicketsRequest
+	68, // 95: api.KitchenService.StartTicket:input_type -> api.StartTicketRequest
+	69, // 96: api.KitchenService.BumpTicket:input_type -> api.BumpTicketRequest
+	70, // 97: api.KitchenService.RecallTicket:input_type -> api.RecallTicketRequest
+	71, // 98: api.KitchenService.VoidTicket:input_type -> api.VoidTicketRequest
+	5,  // 99: api.OrderService.CreateOrder:output_type -> api.Order
+	5,  // 100: api.OrderService.GetOrder:output_type -> api.Order
+	11, // 101: api.OrderService.GetUserOrders:output_type -> api.GetUserOrdersResponse
+	5,  // 102: api.OrderService.PatchOrderStatus:output_type -> api.Order
+	5,  // 103: api.OrderService.CancelOrder:output_type -> api.Order
+	5,  // 104: api.OrderService.QuoteOrder:output_type -> api.Order
+	14, // 105: api.OrderService.WatchOrder:output_type -> api.OrderStatusEvent
+	19, // 106: api.StockService.AddStockItem:output_type -> api.AddStockItemResponse
+	23, // 107: api.StockService.BookItems:output_type -> api.BookItemsResponse
+	25, // 108: api.StockService.ReleaseBookedItems:output_type -> api.ReleaseBookedItemsResponse
+	21, // 109: api.StockService.RemoveStockItem:output_type -> api.RemoveStockItemResponse
+	28, // 110: api.StockService.VerifyStock:output_type -> api.VerifyStockResponse
+	31, // 111: api.StockService.GetStockItem:output_type -> api.GetStockItemResponse
+	33, // 112: api.StockService.GetStockItems:output_type -> api.GetStockItemsResponse
+	35, // 113: api.StockService.FinalizeBooking:output_type -> api.FinalizeBookingResponse
+	37, // 114: api.StockService.ReturnFinalizedItems:output_type -> api.ReturnFinalizedItemsResponse
+	39, // 115: api.StockService.ListStockItems:output_type -> api.ListStockItemsResponse
+	41, // 116: api.StockService.AdjustStockQuantity:output_type -> api.AdjustStockQuantityResponse
+	42, // 117: api.PricingService.CreatePriceList:output_type -> api.PriceList
+	42, // 118: api.PricingService.SetPrices:output_type -> api.PriceList
+	47, // 119: api.PricingService.ListPriceLists:output_type -> api.ListPriceListsResponse
+	49, // 120: api.PricingService.DeletePriceList:output_type -> api.DeletePriceListResponse
+	52, // 121: api.PricingService.ResolvePrices:output_type -> api.ResolvePricesResponse
+	55, // 122: api.PaymentService.CreatePaymentIntent:output_type -> api.CreatePaymentIntentResponse
+	57, // 123: api.PaymentService.CapturePayment:output_type -> api.CapturePaymentResponse
+	59, // 124: api.PaymentService.RefundPayment:output_type -> api.RefundPaymentResponse
+	61, // 125: api.PaymentService.VoidPayment:output_type -> api.VoidPaymentResponse
+	63, // 126: api.PaymentService.GetPayment:output_type -> api.GetPaymentResponse
+	67, // 127: api.KitchenService.ListTickets:output_type -> api.ListTicketsResponse
+	65, // 128: api.KitchenService.StartTicket:output_type -> api.Ticket
+	65, // 129: api.KitchenService.BumpTicket:output_type -> api.Ticket
+	65, // 130: api.KitchenService.RecallTicket:output_type -> api.Ticket
+	65, // 131: api.KitchenService.VoidTicket:output_type -> api.Ticket
+	99, // [99:132] is the sub-list for method output_type
+	66, // [66:99] is the sub-list for method input_type
+	66, // [66:66] is the sub-list for extension type_name
+	66, // [66:66] is the sub-list for extension extendee
+	0,  // [0:66] is the sub-list for field type_name
 }
 
 func init() { file_api_oms_proto_init() }
@@ -1581,10 +4886,10 @@ func file_api_oms_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_oms_proto_rawDesc), len(file_api_oms_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   24,
+			NumEnums:      4,
+			NumMessages:   68,
 			NumExtensions: 0,
-			NumServices:   2,
+			NumServices:   5,
 		},
 		GoTypes:           file_api_oms_proto_goTypes,
 		DependencyIndexes: file_api_oms_proto_depIdxs,
@@ -23,6 +23,9 @@ const (
 	OrderService_GetOrder_FullMethodName         = "/api.OrderService/GetOrder"
 	OrderService_GetUserOrders_FullMethodName    = "/api.OrderService/GetUserOrders"
 	OrderService_PatchOrderStatus_FullMethodName = "/api.OrderService/PatchOrderStatus"
+	OrderService_CancelOrder_FullMethodName      = "/api.OrderService/CancelOrder"
+	OrderService_QuoteOrder_FullMethodName       = "/api.OrderService/QuoteOrder"
+	OrderService_WatchOrder_FullMethodName       = "/api.OrderService/WatchOrder"
 )
 
 // OrderServiceClient is the client API for OrderService service.
@@ -33,6 +36,14 @@ type OrderServiceClient interface {
 	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
 	GetUserOrders(ctx context.Context, in *GetUserOrdersRequest, opts ...grpc.CallOption) (*GetUserOrdersResponse, error)
 	PatchOrderStatus(ctx context.Context, in *PatchOrderStatusRequest, opts ...grpc.CallOption) (*Order, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// QuoteOrder prices an order the way CreateOrder would, without placing
+	// it.
+	QuoteOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	// WatchOrder streams the status changes of an order: first those already
+	// recorded after AfterSequence, then new ones as they happen. The stream
+	// ends once the order reaches a status it cannot leave.
+	WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderStatusEvent], error)
 }
 
 type orderServiceClient struct {
@@ -83,6 +94,45 @@ func (c *orderServiceClient) PatchOrderStatus(ctx context.Context, in *PatchOrde
 	return out, nil
 }
 
+func (c *orderServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_CancelOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) QuoteOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Order)
+	err := c.cc.Invoke(ctx, OrderService_QuoteOrder_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *orderServiceClient) WatchOrder(ctx context.Context, in *WatchOrderRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[OrderStatusEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &OrderService_ServiceDesc.Streams[0], OrderService_WatchOrder_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchOrderRequest, OrderStatusEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderService_WatchOrderClient = grpc.ServerStreamingClient[OrderStatusEvent]
+
 // OrderServiceServer is the server API for OrderService service.
 // All implementations must embed UnimplementedOrderServiceServer
 // for forward compatibility.
@@ -91,6 +141,14 @@ type OrderServiceServer interface {
 	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
 	GetUserOrders(context.Context, *GetUserOrdersRequest) (*GetUserOrdersResponse, error)
 	PatchOrderStatus(context.Context, *PatchOrderStatusRequest) (*Order, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*Order, error)
+	// QuoteOrder prices an order the way CreateOrder would, without placing
+	// it.
+	QuoteOrder(context.Context, *CreateOrderRequest) (*Order, error)
+	// WatchOrder streams the status changes of an order: first those already
+	// recorded after AfterSequence, then new ones as they happen. The stream
+	// ends once the order reaches a status it cannot leave.
+	WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderStatusEvent]) error
 	mustEmbedUnimplementedOrderServiceServer()
 }
 
@@ -113,6 +171,15 @@ func (UnimplementedOrderServiceServer) GetUserOrders(context.Context, *GetUserOr
 func (UnimplementedOrderServiceServer) PatchOrderStatus(context.Context, *PatchOrderStatusRequest) (*Order, error) {
 	return nil, status.Error(codes.Unimplemented, "method PatchOrderStatus not implemented")
 }
+func (UnimplementedOrderServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*Order, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) QuoteOrder(context.Context, *CreateOrderRequest) (*Order, error) {
+	return nil, status.Error(codes.Unimplemented, "method QuoteOrder not implemented")
+}
+func (UnimplementedOrderServiceServer) WatchOrder(*WatchOrderRequest, grpc.ServerStreamingServer[OrderStatusEvent]) error {
+	return status.Error(codes.Unimplemented, "method WatchOrder not implemented")
+}
 func (UnimplementedOrderServiceServer) mustEmbedUnimplementedOrderServiceServer() {}
 func (UnimplementedOrderServiceServer) testEmbeddedByValue()                      {}
 
@@ -206,6 +273,53 @@ func _OrderService_PatchOrderStatus_Handler(srv interface{}, ctx context.Context
 	return interceptor(ctx, in, info, handler)
 }
 
+func _OrderService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_QuoteOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(OrderServiceServer).QuoteOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: OrderService_QuoteOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(OrderServiceServer).QuoteOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _OrderService_WatchOrder_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrderRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(OrderServiceServer).WatchOrder(m, &grpc.GenericServerStream[WatchOrderRequest, OrderStatusEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type OrderService_WatchOrderServer = grpc.ServerStreamingServer[OrderStatusEvent]
+
 // OrderService_ServiceDesc is the grpc.ServiceDesc for OrderService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -229,19 +343,37 @@ var OrderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "PatchOrderStatus",
 			Handler:    _OrderService_PatchOrderStatus_Handler,
 		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _OrderService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "QuoteOrder",
+			Handler:    _OrderService_QuoteOrder_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrder",
+			Handler:       _OrderService_WatchOrder_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/oms.proto",
 }
 
 const (
-	StockService_AddStockItem_FullMethodName       = "/api.StockService/AddStockItem"
-	StockService_BookItems_FullMethodName          = "/api.StockService/BookItems"
-	StockService_ReleaseBookedItems_FullMethodName = "/api.StockService/ReleaseBookedItems"
-	StockService_RemoveStockItem_FullMethodName    = "/api.StockService/RemoveStockItem"
-	StockService_VerifyStock_FullMethodName        = "/api.StockService/VerifyStock"
-	StockService_GetStockItem_FullMethodName       = "/api.StockService/GetStockItem"
-	StockService_FinalizeBooking_FullMethodName    = "/api.StockService/FinalizeBooking"
+	StockService_AddStockItem_FullMethodName         = "/api.StockService/AddStockItem"
+	StockService_BookItems_FullMethodName            = "/api.StockService/BookItems"
+	StockService_ReleaseBookedItems_FullMethodName   = "/api.StockService/ReleaseBookedItems"
+	StockService_RemoveStockItem_FullMethodName      = "/api.StockService/RemoveStockItem"
+	StockService_VerifyStock_FullMethodName          = "/api.StockService/VerifyStock"
+	StockService_GetStockItem_FullMethodName         = "/api.StockService/GetStockItem"
+	StockService_GetStockItems_FullMethodName        = "/api.StockService/GetStockItems"
+	StockService_FinalizeBooking_FullMethodName      = "/api.StockService/FinalizeBooking"
+	StockService_ReturnFinalizedItems_FullMethodName = "/api.StockService/ReturnFinalizedItems"
+	StockService_ListStockItems_FullMethodName       = "/api.StockService/ListStockItems"
+	StockService_AdjustStockQuantity_FullMethodName  = "/api.StockService/AdjustStockQuantity"
 )
 
 // StockServiceClient is the client API for StockService service.
@@ -254,7 +386,13 @@ type StockServiceClient interface {
 	RemoveStockItem(ctx context.Context, in *RemoveStockItemRequest, opts ...grpc.CallOption) (*RemoveStockItemResponse, error)
 	VerifyStock(ctx context.Context, in *VerifyStockRequest, opts ...grpc.CallOption) (*VerifyStockResponse, error)
 	GetStockItem(ctx context.Context, in *GetStockItemRequest, opts ...grpc.CallOption) (*GetStockItemResponse, error)
+	GetStockItems(ctx context.Context, in *GetStockItemsRequest, opts ...grpc.CallOption) (*GetStockItemsResponse, error)
 	FinalizeBooking(ctx context.Context, in *FinalizeBookingRequest, opts ...grpc.CallOption) (*FinalizeBookingResponse, error)
+	// ReturnFinalizedItems puts the stock deducted by FinalizeBooking back,
+	// e.g. when a paid order is canceled. It is safe to repeat.
+	ReturnFinalizedItems(ctx context.Context, in *ReturnFinalizedItemsRequest, opts ...grpc.CallOption) (*ReturnFinalizedItemsResponse, error)
+	ListStockItems(ctx context.Context, in *ListStockItemsRequest, opts ...grpc.CallOption) (*ListStockItemsResponse, error)
+	AdjustStockQuantity(ctx context.Context, in *AdjustStockQuantityRequest, opts ...grpc.CallOption) (*AdjustStockQuantityResponse, error)
 }
 
 type stockServiceClient struct {
@@ -325,6 +463,16 @@ func (c *stockServiceClient) GetStockItem(ctx context.Context, in *GetStockItemR
 	return out, nil
 }
 
+func (c *stockServiceClient) GetStockItems(ctx context.Context, in *GetStockItemsRequest, opts ...grpc.CallOption) (*GetStockItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetStockItemsResponse)
+	err := c.cc.Invoke(ctx, StockService_GetStockItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *stockServiceClient) FinalizeBooking(ctx context.Context, in *FinalizeBookingRequest, opts ...grpc.CallOption) (*FinalizeBookingResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(FinalizeBookingResponse)
@@ -335,6 +483,36 @@ func (c *stockServiceClient) FinalizeBooking(ctx context.Context, in *FinalizeBo
 	return out, nil
 }
 
+func (c *stockServiceClient) ReturnFinalizedItems(ctx context.Context, in *ReturnFinalizedItemsRequest, opts ...grpc.CallOption) (*ReturnFinalizedItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReturnFinalizedItemsResponse)
+	err := c.cc.Invoke(ctx, StockService_ReturnFinalizedItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) ListStockItems(ctx context.Context, in *ListStockItemsRequest, opts ...grpc.CallOption) (*ListStockItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListStockItemsResponse)
+	err := c.cc.Invoke(ctx, StockService_ListStockItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *stockServiceClient) AdjustStockQuantity(ctx context.Context, in *AdjustStockQuantityRequest, opts ...grpc.CallOption) (*AdjustStockQuantityResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(AdjustStockQuantityResponse)
+	err := c.cc.Invoke(ctx, StockService_AdjustStockQuantity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StockServiceServer is the server API for StockService service.
 // All implementations must embed UnimplementedStockServiceServer
 // for forward compatibility.
@@ -345,7 +523,13 @@ type StockServiceServer interface {
 	RemoveStockItem(context.Context, *RemoveStockItemRequest) (*RemoveStockItemResponse, error)
 	VerifyStock(context.Context, *VerifyStockRequest) (*VerifyStockResponse, error)
 	GetStockItem(context.Context, *GetStockItemRequest) (*GetStockItemResponse, error)
+	GetStockItems(context.Context, *GetStockItemsRequest) (*GetStockItemsResponse, error)
 	FinalizeBooking(context.Context, *FinalizeBookingRequest) (*FinalizeBookingResponse, error)
+	// ReturnFinalizedItems puts the stock deducted by FinalizeBooking back,
+	// e.g. when a paid order is canceled. It is safe to repeat.
+	ReturnFinalizedItems(context.Context, *ReturnFinalizedItemsRequest) (*ReturnFinalizedItemsResponse, error)
+	ListStockItems(context.Context, *ListStockItemsRequest) (*ListStockItemsResponse, error)
+	AdjustStockQuantity(context.Context, *AdjustStockQuantityRequest) (*AdjustStockQuantityResponse, error)
 	mustEmbedUnimplementedStockServiceServer()
 }
 
@@ -374,9 +558,21 @@ func (UnimplementedStockServiceServer) VerifyStock(context.Context, *VerifyStock
 func (UnimplementedStockServiceServer) GetStockItem(context.Context, *GetStockItemRequest) (*GetStockItemResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetStockItem not implemented")
 }
+func (UnimplementedStockServiceServer) GetStockItems(context.Context, *GetStockItemsRequest) (*GetStockItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetStockItems not implemented")
+}
 func (UnimplementedStockServiceServer) FinalizeBooking(context.Context, *FinalizeBookingRequest) (*FinalizeBookingResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method FinalizeBooking not implemented")
 }
+func (UnimplementedStockServiceServer) ReturnFinalizedItems(context.Context, *ReturnFinalizedItemsRequest) (*ReturnFinalizedItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReturnFinalizedItems not implemented")
+}
+func (UnimplementedStockServiceServer) ListStockItems(context.Context, *ListStockItemsRequest) (*ListStockItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListStockItems not implemented")
+}
+func (UnimplementedStockServiceServer) AdjustStockQuantity(context.Context, *AdjustStockQuantityRequest) (*AdjustStockQuantityResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method AdjustStockQuantity not implemented")
+}
 func (UnimplementedStockServiceServer) mustEmbedUnimplementedStockServiceServer() {}
 func (UnimplementedStockServiceServer) testEmbeddedByValue()                      {}
 
@@ -506,6 +702,24 @@ func _StockService_GetStockItem_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_GetStockItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetStockItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).GetStockItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_GetStockItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).GetStockItems(ctx, req.(*GetStockItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _StockService_FinalizeBooking_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(FinalizeBookingRequest)
 	if err := dec(in); err != nil {
@@ -524,6 +738,60 @@ func _StockService_FinalizeBooking_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _StockService_ReturnFinalizedItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnFinalizedItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ReturnFinalizedItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ReturnFinalizedItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ReturnFinalizedItems(ctx, req.(*ReturnFinalizedItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_ListStockItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListStockItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).ListStockItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_ListStockItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).ListStockItems(ctx, req.(*ListStockItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StockService_AdjustStockQuantity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AdjustStockQuantityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StockServiceServer).AdjustStockQuantity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StockService_AdjustStockQuantity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StockServiceServer).AdjustStockQuantity(ctx, req.(*AdjustStockQuantityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StockService_ServiceDesc is the grpc.ServiceDesc for StockService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -555,10 +823,810 @@ var StockService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetStockItem",
 			Handler:    _StockService_GetStockItem_Handler,
 		},
+		{
+			MethodName: "GetStockItems",
+			Handler:    _StockService_GetStockItems_Handler,
+		},
 		{
 			MethodName: "FinalizeBooking",
 			Handler:    _StockService_FinalizeBooking_Handler,
 		},
+		{
+			MethodName: "ReturnFinalizedItems",
+			Handler:    _StockService_ReturnFinalizedItems_Handler,
+		},
+		{
+			MethodName: "ListStockItems",
+			Handler:    _StockService_ListStockItems_Handler,
+		},
+		{
+			MethodName: "AdjustStockQuantity",
+			Handler:    _StockService_AdjustStockQuantity_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/oms.proto",
+}
+
+const (
+	PricingService_CreatePriceList_FullMethodName = "/api.PricingService/CreatePriceList"
+	PricingService_SetPrices_FullMethodName       = "/api.PricingService/SetPrices"
+	PricingService_ListPriceLists_FullMethodName  = "/api.PricingService/ListPriceLists"
+	PricingService_DeletePriceList_FullMethodName = "/api.PricingService/DeletePriceList"
+	PricingService_ResolvePrices_FullMethodName   = "/api.PricingService/ResolvePrices"
+)
+
+// PricingServiceClient is the client API for PricingService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PricingServiceClient interface {
+	CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error)
+	SetPrices(ctx context.Context, in *SetPricesRequest, opts ...grpc.CallOption) (*PriceList, error)
+	ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error)
+	DeletePriceList(ctx context.Context, in *DeletePriceListRequest, opts ...grpc.CallOption) (*DeletePriceListResponse, error)
+	ResolvePrices(ctx context.Context, in *ResolvePricesRequest, opts ...grpc.CallOption) (*ResolvePricesResponse, error)
+}
+
+type pricingServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPricingServiceClient(cc grpc.ClientConnInterface) PricingServiceClient {
+	return &pricingServiceClient{cc}
+}
+
+func (c *pricingServiceClient) CreatePriceList(ctx context.Context, in *CreatePriceListRequest, opts ...grpc.CallOption) (*PriceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceList)
+	err := c.cc.Invoke(ctx, PricingService_CreatePriceList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) SetPrices(ctx context.Context, in *SetPricesRequest, opts ...grpc.CallOption) (*PriceList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PriceList)
+	err := c.cc.Invoke(ctx, PricingService_SetPrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ListPriceLists(ctx context.Context, in *ListPriceListsRequest, opts ...grpc.CallOption) (*ListPriceListsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListPriceListsResponse)
+	err := c.cc.Invoke(ctx, PricingService_ListPriceLists_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) DeletePriceList(ctx context.Context, in *DeletePriceListRequest, opts ...grpc.CallOption) (*DeletePriceListResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeletePriceListResponse)
+	err := c.cc.Invoke(ctx, PricingService_DeletePriceList_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pricingServiceClient) ResolvePrices(ctx context.Context, in *ResolvePricesRequest, opts ...grpc.CallOption) (*ResolvePricesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResolvePricesResponse)
+	err := c.cc.Invoke(ctx, PricingService_ResolvePrices_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PricingServiceServer is the server API for PricingService service.
+// All implementations must embed UnimplementedPricingServiceServer
+// for forward compatibility.
+type PricingServiceServer interface {
+	CreatePriceList(context.Context, *CreatePriceListRequest) (*PriceList, error)
+	SetPrices(context.Context, *SetPricesRequest) (*PriceList, error)
+	ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error)
+	DeletePriceList(context.Context, *DeletePriceListRequest) (*DeletePriceListResponse, error)
+	ResolvePrices(context.Context, *ResolvePricesRequest) (*ResolvePricesResponse, error)
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+// UnimplementedPricingServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPricingServiceServer struct{}
+
+func (UnimplementedPricingServiceServer) CreatePriceList(context.Context, *CreatePriceListRequest) (*PriceList, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePriceList not implemented")
+}
+func (UnimplementedPricingServiceServer) SetPrices(context.Context, *SetPricesRequest) (*PriceList, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetPrices not implemented")
+}
+func (UnimplementedPricingServiceServer) ListPriceLists(context.Context, *ListPriceListsRequest) (*ListPriceListsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListPriceLists not implemented")
+}
+func (UnimplementedPricingServiceServer) DeletePriceList(context.Context, *DeletePriceListRequest) (*DeletePriceListResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeletePriceList not implemented")
+}
+func (UnimplementedPricingServiceServer) ResolvePrices(context.Context, *ResolvePricesRequest) (*ResolvePricesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResolvePrices not implemented")
+}
+func (UnimplementedPricingServiceServer) mustEmbedUnimplementedPricingServiceServer() {}
+func (UnimplementedPricingServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePricingServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PricingServiceServer will
+// result in compilation errors.
+type UnsafePricingServiceServer interface {
+	mustEmbedUnimplementedPricingServiceServer()
+}
+
+func RegisterPricingServiceServer(s grpc.ServiceRegistrar, srv PricingServiceServer) {
+	// If the following call panics, it indicates UnimplementedPricingServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PricingService_ServiceDesc, srv)
+}
+
+func _PricingService_CreatePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePriceListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).CreatePriceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_CreatePriceList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).CreatePriceList(ctx, req.(*CreatePriceListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_SetPrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetPricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).SetPrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_SetPrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).SetPrices(ctx, req.(*SetPricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ListPriceLists_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListPriceListsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ListPriceLists(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ListPriceLists_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ListPriceLists(ctx, req.(*ListPriceListsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_DeletePriceList_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeletePriceListRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).DeletePriceList(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_DeletePriceList_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).DeletePriceList(ctx, req.(*DeletePriceListRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PricingService_ResolvePrices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResolvePricesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PricingServiceServer).ResolvePrices(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PricingService_ResolvePrices_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PricingServiceServer).ResolvePrices(ctx, req.(*ResolvePricesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PricingService_ServiceDesc is the grpc.ServiceDesc for PricingService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PricingService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.PricingService",
+	HandlerType: (*PricingServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePriceList",
+			Handler:    _PricingService_CreatePriceList_Handler,
+		},
+		{
+			MethodName: "SetPrices",
+			Handler:    _PricingService_SetPrices_Handler,
+		},
+		{
+			MethodName: "ListPriceLists",
+			Handler:    _PricingService_ListPriceLists_Handler,
+		},
+		{
+			MethodName: "DeletePriceList",
+			Handler:    _PricingService_DeletePriceList_Handler,
+		},
+		{
+			MethodName: "ResolvePrices",
+			Handler:    _PricingService_ResolvePrices_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/oms.proto",
+}
+
+const (
+	PaymentService_CreatePaymentIntent_FullMethodName = "/api.PaymentService/CreatePaymentIntent"
+	PaymentService_CapturePayment_FullMethodName      = "/api.PaymentService/CapturePayment"
+	PaymentService_RefundPayment_FullMethodName       = "/api.PaymentService/RefundPayment"
+	PaymentService_VoidPayment_FullMethodName         = "/api.PaymentService/VoidPayment"
+	PaymentService_GetPayment_FullMethodName          = "/api.PaymentService/GetPayment"
+)
+
+// PaymentServiceClient is the client API for PaymentService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PaymentServiceClient interface {
+	CreatePaymentIntent(ctx context.Context, in *CreatePaymentIntentRequest, opts ...grpc.CallOption) (*CreatePaymentIntentResponse, error)
+	CapturePayment(ctx context.Context, in *CapturePaymentRequest, opts ...grpc.CallOption) (*CapturePaymentResponse, error)
+	RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error)
+	// VoidPayment fails a pending payment, so that it can no longer be
+	// captured, e.g. for an order that was canceled in the meantime.
+	VoidPayment(ctx context.Context, in *VoidPaymentRequest, opts ...grpc.CallOption) (*VoidPaymentResponse, error)
+	GetPayment(ctx context.Context, in *GetPaymentRequest, opts ...grpc.CallOption) (*GetPaymentResponse, error)
+}
+
+type paymentServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPaymentServiceClient(cc grpc.ClientConnInterface) PaymentServiceClient {
+	return &paymentServiceClient{cc}
+}
+
+func (c *paymentServiceClient) CreatePaymentIntent(ctx context.Context, in *CreatePaymentIntentRequest, opts ...grpc.CallOption) (*CreatePaymentIntentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreatePaymentIntentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CreatePaymentIntent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) CapturePayment(ctx context.Context, in *CapturePaymentRequest, opts ...grpc.CallOption) (*CapturePaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CapturePaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_CapturePayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) RefundPayment(ctx context.Context, in *RefundPaymentRequest, opts ...grpc.CallOption) (*RefundPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_RefundPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) VoidPayment(ctx context.Context, in *VoidPaymentRequest, opts ...grpc.CallOption) (*VoidPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VoidPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_VoidPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *paymentServiceClient) GetPayment(ctx context.Context, in *GetPaymentRequest, opts ...grpc.CallOption) (*GetPaymentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetPaymentResponse)
+	err := c.cc.Invoke(ctx, PaymentService_GetPayment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PaymentServiceServer is the server API for PaymentService service.
+// All implementations must embed UnimplementedPaymentServiceServer
+// for forward compatibility.
+type PaymentServiceServer interface {
+	CreatePaymentIntent(context.Context, *CreatePaymentIntentRequest) (*CreatePaymentIntentResponse, error)
+	CapturePayment(context.Context, *CapturePaymentRequest) (*CapturePaymentResponse, error)
+	RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error)
+	// VoidPayment fails a pending payment, so that it can no longer be
+	// captured, e.g. for an order that was canceled in the meantime.
+	VoidPayment(context.Context, *VoidPaymentRequest) (*VoidPaymentResponse, error)
+	GetPayment(context.Context, *GetPaymentRequest) (*GetPaymentResponse, error)
+	mustEmbedUnimplementedPaymentServiceServer()
+}
+
+// UnimplementedPaymentServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedPaymentServiceServer struct{}
+
+func (UnimplementedPaymentServiceServer) CreatePaymentIntent(context.Context, *CreatePaymentIntentRequest) (*CreatePaymentIntentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreatePaymentIntent not implemented")
+}
+func (UnimplementedPaymentServiceServer) CapturePayment(context.Context, *CapturePaymentRequest) (*CapturePaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CapturePayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) RefundPayment(context.Context, *RefundPaymentRequest) (*RefundPaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RefundPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) VoidPayment(context.Context, *VoidPaymentRequest) (*VoidPaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VoidPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) GetPayment(context.Context, *GetPaymentRequest) (*GetPaymentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetPayment not implemented")
+}
+func (UnimplementedPaymentServiceServer) mustEmbedUnimplementedPaymentServiceServer() {}
+func (UnimplementedPaymentServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafePaymentServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PaymentServiceServer will
+// result in compilation errors.
+type UnsafePaymentServiceServer interface {
+	mustEmbedUnimplementedPaymentServiceServer()
+}
+
+func RegisterPaymentServiceServer(s grpc.ServiceRegistrar, srv PaymentServiceServer) {
+	// If the following call panics, it indicates UnimplementedPaymentServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&PaymentService_ServiceDesc, srv)
+}
+
+func _PaymentService_CreatePaymentIntent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreatePaymentIntentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CreatePaymentIntent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CreatePaymentIntent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CreatePaymentIntent(ctx, req.(*CreatePaymentIntentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_CapturePayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CapturePaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).CapturePayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_CapturePayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).CapturePayment(ctx, req.(*CapturePaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_RefundPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_RefundPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).RefundPayment(ctx, req.(*RefundPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_VoidPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).VoidPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_VoidPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).VoidPayment(ctx, req.(*VoidPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PaymentService_GetPayment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPaymentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PaymentServiceServer).GetPayment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PaymentService_GetPayment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PaymentServiceServer).GetPayment(ctx, req.(*GetPaymentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PaymentService_ServiceDesc is the grpc.ServiceDesc for PaymentService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PaymentService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.PaymentService",
+	HandlerType: (*PaymentServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreatePaymentIntent",
+			Handler:    _PaymentService_CreatePaymentIntent_Handler,
+		},
+		{
+			MethodName: "CapturePayment",
+			Handler:    _PaymentService_CapturePayment_Handler,
+		},
+		{
+			MethodName: "RefundPayment",
+			Handler:    _PaymentService_RefundPayment_Handler,
+		},
+		{
+			MethodName: "VoidPayment",
+			Handler:    _PaymentService_VoidPayment_Handler,
+		},
+		{
+			MethodName: "GetPayment",
+			Handler:    _PaymentService_GetPayment_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "api/oms.proto",
+}
+
+const (
+	KitchenService_ListTickets_FullMethodName  = "/api.KitchenService/ListTickets"
+	KitchenService_StartTicket_FullMethodName  = "/api.KitchenService/StartTicket"
+	KitchenService_BumpTicket_FullMethodName   = "/api.KitchenService/BumpTicket"
+	KitchenService_RecallTicket_FullMethodName = "/api.KitchenService/RecallTicket"
+	KitchenService_VoidTicket_FullMethodName   = "/api.KitchenService/VoidTicket"
+)
+
+// KitchenServiceClient is the client API for KitchenService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// KitchenService backs the kitchen display: cooks start tickets from their
+// station's queue and bump them once done.
+type KitchenServiceClient interface {
+	ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error)
+	// StartTicket fails if the station already works on as many tickets as
+	// its capacity allows.
+	StartTicket(ctx context.Context, in *StartTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	BumpTicket(ctx context.Context, in *BumpTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	// RecallTicket brings a ticket bumped by mistake back to its station.
+	// It only works until the whole order is ready.
+	RecallTicket(ctx context.Context, in *RecallTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+	// VoidTicket gives up on a ticket the station cannot make. The order
+	// fails and the customer is refunded.
+	VoidTicket(ctx context.Context, in *VoidTicketRequest, opts ...grpc.CallOption) (*Ticket, error)
+}
+
+type kitchenServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewKitchenServiceClient(cc grpc.ClientConnInterface) KitchenServiceClient {
+	return &kitchenServiceClient{cc}
+}
+
+func (c *kitchenServiceClient) ListTickets(ctx context.Context, in *ListTicketsRequest, opts ...grpc.CallOption) (*ListTicketsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListTicketsResponse)
+	err := c.cc.Invoke(ctx, KitchenService_ListTickets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kitchenServiceClient) StartTicket(ctx context.Context, in *StartTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, KitchenService_StartTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kitchenServiceClient) BumpTicket(ctx context.Context, in *BumpTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, KitchenService_BumpTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kitchenServiceClient) RecallTicket(ctx context.Context, in *RecallTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, KitchenService_RecallTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *kitchenServiceClient) VoidTicket(ctx context.Context, in *VoidTicketRequest, opts ...grpc.CallOption) (*Ticket, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Ticket)
+	err := c.cc.Invoke(ctx, KitchenService_VoidTicket_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// KitchenServiceServer is the server API for KitchenService service.
+// All implementations must embed UnimplementedKitchenServiceServer
+// for forward compatibility.
+//
+// KitchenService backs the kitchen display: cooks start tickets from their
+// station's queue and bump them once done.
+type KitchenServiceServer interface {
+	ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error)
+	// StartTicket fails if the station already works on as many tickets as
+	// its capacity allows.
+	StartTicket(context.Context, *StartTicketRequest) (*Ticket, error)
+	BumpTicket(context.Context, *BumpTicketRequest) (*Ticket, error)
+	// RecallTicket brings a ticket bumped by mistake back to its station.
+	// It only works until the whole order is ready.
+	RecallTicket(context.Context, *RecallTicketRequest) (*Ticket, error)
+	// VoidTicket gives up on a ticket the station cannot make. The order
+	// fails and the customer is refunded.
+	VoidTicket(context.Context, *VoidTicketRequest) (*Ticket, error)
+	mustEmbedUnimplementedKitchenServiceServer()
+}
+
+// UnimplementedKitchenServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedKitchenServiceServer struct{}
+
+func (UnimplementedKitchenServiceServer) ListTickets(context.Context, *ListTicketsRequest) (*ListTicketsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListTickets not implemented")
+}
+func (UnimplementedKitchenServiceServer) StartTicket(context.Context, *StartTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method StartTicket not implemented")
+}
+func (UnimplementedKitchenServiceServer) BumpTicket(context.Context, *BumpTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method BumpTicket not implemented")
+}
+func (UnimplementedKitchenServiceServer) RecallTicket(context.Context, *RecallTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method RecallTicket not implemented")
+}
+func (UnimplementedKitchenServiceServer) VoidTicket(context.Context, *VoidTicketRequest) (*Ticket, error) {
+	return nil, status.Error(codes.Unimplemented, "method VoidTicket not implemented")
+}
+func (UnimplementedKitchenServiceServer) mustEmbedUnimplementedKitchenServiceServer() {}
+func (UnimplementedKitchenServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeKitchenServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to KitchenServiceServer will
+// result in compilation errors.
+type UnsafeKitchenServiceServer interface {
+	mustEmbedUnimplementedKitchenServiceServer()
+}
+
+func RegisterKitchenServiceServer(s grpc.ServiceRegistrar, srv KitchenServiceServer) {
+	// If the following call panics, it indicates UnimplementedKitchenServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&KitchenService_ServiceDesc, srv)
+}
+
+func _KitchenService_ListTickets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListTicketsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KitchenServiceServer).ListTickets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KitchenService_ListTickets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KitchenServiceServer).ListTickets(ctx, req.(*ListTicketsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KitchenService_StartTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(StartTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KitchenServiceServer).StartTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KitchenService_StartTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KitchenServiceServer).StartTicket(ctx, req.(*StartTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KitchenService_BumpTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BumpTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KitchenServiceServer).BumpTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KitchenService_BumpTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KitchenServiceServer).BumpTicket(ctx, req.(*BumpTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KitchenService_RecallTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RecallTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KitchenServiceServer).RecallTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KitchenService_RecallTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KitchenServiceServer).RecallTicket(ctx, req.(*RecallTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _KitchenService_VoidTicket_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VoidTicketRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KitchenServiceServer).VoidTicket(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KitchenService_VoidTicket_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KitchenServiceServer).VoidTicket(ctx, req.(*VoidTicketRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// KitchenService_ServiceDesc is the grpc.ServiceDesc for KitchenService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var KitchenService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "api.KitchenService",
+	HandlerType: (*KitchenServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ListTickets",
+			Handler:    _KitchenService_ListTickets_Handler,
+		},
+		{
+			MethodName: "StartTicket",
+			Handler:    _KitchenService_StartTicket_Handler,
+		},
+		{
+			MethodName: "BumpTicket",
+			Handler:    _KitchenService_BumpTicket_Handler,
+		},
+		{
+			MethodName: "RecallTicket",
+			Handler:    _KitchenService_RecallTicket_Handler,
+		},
+		{
+			MethodName: "VoidTicket",
+			Handler:    _KitchenService_VoidTicket_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "api/oms.proto",
@@ -1,8 +1,84 @@
 package common
 
-import "errors"
+import (
+	"fmt"
+	"strings"
+)
 
 var (
-	ErrNoItems = errors.New("items must contain at least one item")
-	ErrNoStock = errors.New("no stock available for one or more items")
+	ErrNoItems = &InvalidArgumentError{Field: "items", Reason: "must contain at least one item"}
 )
+
+// NotFoundError reports that a resource does not exist.
+type NotFoundError struct {
+	Resource string
+	ID       string
+}
+
+func NotFound(resource, id string) error {
+	return &NotFoundError{Resource: resource, ID: id}
+}
+
+func (e *NotFoundError) Error() string {
+	return fmt.Sprintf("%s %s not found", e.Resource, e.ID)
+}
+
+// ConflictError reports that a resource is not in a state that allows the
+// requested operation, e.g. an illegal order status transition.
+type ConflictError struct {
+	Resource string
+	ID       string
+	Reason   string
+}
+
+func Conflict(resource, id, reason string) error {
+	return &ConflictError{Resource: resource, ID: id, Reason: reason}
+}
+
+func (e *ConflictError) Error() string {
+	return e.Reason
+}
+
+// StockShortage describes a single item that cannot be supplied in the
+// requested quantity.
+type StockShortage struct {
+	ItemID    string `json:"item_id"`
+	Requested int32  `json:"requested"`
+	Available int32  `json:"available"`
+}
+
+// InsufficientStockError reports the items an order cannot be fulfilled
+// with.
+type InsufficientStockError struct {
+	Items []StockShortage
+}
+
+func InsufficientStock(items ...StockShortage) error {
+	return &InsufficientStockError{Items: items}
+}
+
+func (e *InsufficientStockError) Error() string {
+	if len(e.Items) == 0 {
+		return "no stock available for one or more items"
+	}
+
+	parts := make([]string, 0, len(e.Items))
+	for _, it := range e.Items {
+		parts = append(parts, fmt.Sprintf("%s (requested %d, available %d)", it.ItemID, it.Requested, it.Available))
+	}
+	return "insufficient stock for " + strings.Join(parts, ", ")
+}
+
+// InvalidArgumentError reports a malformed request field.
+type InvalidArgumentError struct {
+	Field  string
+	Reason string
+}
+
+func InvalidArgument(field, reason string) error {
+	return &InvalidArgumentError{Field: field, Reason: reason}
+}
+
+func (e *InvalidArgumentError) Error() string {
+	return e.Field + " " + e.Reason
+}
@@ -0,0 +1,177 @@
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+// Reasons attached to translated errors as errdetails.ErrorInfo, so that
+// clients can tell domain errors sharing a status code apart.
+const (
+	ErrorDomain = "oms"
+
+	ReasonNotFound          = "NOT_FOUND"
+	ReasonConflict          = "CONFLICT"
+	ReasonInsufficientStock = "INSUFFICIENT_STOCK"
+	ReasonInvalidArgument   = "INVALID_ARGUMENT"
+)
+
+// UnaryErrorInterceptor translates errors returned by handlers into gRPC
+// statuses using ToStatus.
+func UnaryErrorInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return nil, ToStatus(err).Err()
+		}
+		return resp, nil
+	}
+}
+
+// StreamErrorInterceptor does for streaming calls what UnaryErrorInterceptor
+// does for unary ones.
+func StreamErrorInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := handler(srv, ss); err != nil {
+			return ToStatus(err).Err()
+		}
+		return nil
+	}
+}
+
+// ToStatus converts err into a gRPC status. Domain errors get their matching
+// code plus errdetails describing them, statuses received from other
+// services are passed on unchanged and anything else becomes Internal.
+func ToStatus(err error) *status.Status {
+	if err == nil {
+		return nil
+	}
+
+	var (
+		notFound *NotFoundError
+		conflict *ConflictError
+		shortage *InsufficientStockError
+		invalid  *InvalidArgumentError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		return withDetails(status.New(codes.NotFound, err.Error()),
+			errorInfo(ReasonNotFound),
+			&errdetails.ResourceInfo{ResourceType: notFound.Resource, ResourceName: notFound.ID},
+		)
+	case errors.As(err, &conflict):
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()),
+			errorInfo(ReasonConflict),
+			&errdetails.ResourceInfo{ResourceType: conflict.Resource, ResourceName: conflict.ID},
+		)
+	case errors.As(err, &shortage):
+		violations := make([]*errdetails.PreconditionFailure_Violation, 0, len(shortage.Items))
+		for _, it := range shortage.Items {
+			violations = append(violations, &errdetails.PreconditionFailure_Violation{
+				Type:        ReasonInsufficientStock,
+				Subject:     it.ItemID,
+				Description: fmt.Sprintf("requested %d, available %d", it.Requested, it.Available),
+			})
+		}
+		return withDetails(status.New(codes.FailedPrecondition, err.Error()),
+			errorInfo(ReasonInsufficientStock),
+			&errdetails.PreconditionFailure{Violations: violations},
+		)
+	case errors.As(err, &invalid):
+		return withDetails(status.New(codes.InvalidArgument, err.Error()),
+			errorInfo(ReasonInvalidArgument),
+			&errdetails.BadRequest{FieldViolations: []*errdetails.BadRequest_FieldViolation{
+				{Field: invalid.Field, Description: invalid.Reason},
+			}},
+		)
+	case errors.Is(err, context.Canceled):
+		return status.New(codes.Canceled, err.Error())
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.New(codes.DeadlineExceeded, err.Error())
+	}
+
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	return status.New(codes.Internal, err.Error())
+}
+
+// FromStatus turns a status produced by ToStatus back into the domain error
+// it describes. Errors that are not statuses, or carry no known reason, are
+// returned unchanged.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st == nil {
+		return err
+	}
+
+	var (
+		info         *errdetails.ErrorInfo
+		resource     *errdetails.ResourceInfo
+		precondition *errdetails.PreconditionFailure
+		badRequest   *errdetails.BadRequest
+	)
+	for _, d := range st.Details() {
+		switch d := d.(type) {
+		case *errdetails.ErrorInfo:
+			info = d
+		case *errdetails.ResourceInfo:
+			resource = d
+		case *errdetails.PreconditionFailure:
+			precondition = d
+		case *errdetails.BadRequest:
+			badRequest = d
+		}
+	}
+	if info == nil || info.Domain != ErrorDomain {
+		return err
+	}
+
+	switch info.Reason {
+	case ReasonNotFound:
+		if resource != nil {
+			return NotFound(resource.ResourceType, resource.ResourceName)
+		}
+	case ReasonConflict:
+		if resource != nil {
+			return Conflict(resource.ResourceType, resource.ResourceName, st.Message())
+		}
+	case ReasonInsufficientStock:
+		if precondition != nil {
+			items := make([]StockShortage, 0, len(precondition.Violations))
+			for _, v := range precondition.Violations {
+				it := StockShortage{ItemID: v.Subject}
+				fmt.Sscanf(v.Description, "requested %d, available %d", &it.Requested, &it.Available)
+				items = append(items, it)
+			}
+			return InsufficientStock(items...)
+		}
+	case ReasonInvalidArgument:
+		if badRequest != nil && len(badRequest.FieldViolations) > 0 {
+			v := badRequest.FieldViolations[0]
+			return InvalidArgument(v.Field, v.Description)
+		}
+	}
+
+	return err
+}
+
+func errorInfo(reason string) *errdetails.ErrorInfo {
+	return &errdetails.ErrorInfo{Reason: reason, Domain: ErrorDomain}
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	withDetails, err := st.WithDetails(details...)
+	if err != nil {
+		return st
+	}
+	return withDetails
+}
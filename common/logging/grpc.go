@@ -0,0 +1,85 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// UnaryServerInterceptor picks the correlation ID up from incoming metadata,
+// or starts a new one, and logs every call.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx = EnsureCorrelationID(ctx, incomingCorrelationID(ctx))
+
+		start := time.Now()
+		resp, err := handler(ctx, req)
+
+		level := slog.LevelDebug
+		if err != nil {
+			level = slog.LevelWarn
+		}
+		slog.Log(ctx, level, "grpc call",
+			"method", info.FullMethod,
+			"code", status.Code(err).String(),
+			"duration", time.Since(start),
+		)
+
+		return resp, err
+	}
+}
+
+// StreamServerInterceptor does for streaming calls what
+// UnaryServerInterceptor does for unary ones.
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx := EnsureCorrelationID(ss.Context(), incomingCorrelationID(ss.Context()))
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// UnaryClientInterceptor forwards the correlation ID of ctx to the callee.
+func UnaryClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply any, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingContext(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientInterceptor forwards the correlation ID of ctx to the callee.
+func StreamClientInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingContext(ctx), desc, cc, method, opts...)
+	}
+}
+
+func incomingCorrelationID(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	if v := md.Get(CorrelationHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func outgoingContext(ctx context.Context) context.Context {
+	id := CorrelationID(ctx)
+	if id == "" {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, CorrelationHeader, id)
+}
+
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
@@ -0,0 +1,104 @@
+// Package logging configures log/slog for the services and carries a
+// correlation ID through contexts, so that a single order can be followed
+// across the gateway, gRPC calls and Kafka events.
+package logging
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+	"os"
+	"strings"
+
+	common "github.com/kiriyms/oms_go-common"
+)
+
+// CorrelationHeader is the key the correlation ID travels under in HTTP
+// headers, gRPC metadata and Kafka message headers.
+const CorrelationHeader = "x-correlation-id"
+
+type correlationKey struct{}
+
+// Init builds the logger described by LOG_FORMAT ("text" or "json") and
+// LOG_LEVEL ("debug", "info", "warn" or "error"), tags it with the service
+// name and installs it as the slog and log default.
+func Init(service string) *slog.Logger {
+	opts := &slog.HandlerOptions{Level: parseLevel(common.GetEnv("LOG_LEVEL", "info"))}
+
+	var h slog.Handler
+	if strings.EqualFold(common.GetEnv("LOG_FORMAT", "text"), "json") {
+		h = slog.NewJSONHandler(os.Stderr, opts)
+	} else {
+		h = slog.NewTextHandler(os.Stderr, opts)
+	}
+
+	logger := slog.New(&correlationHandler{Handler: h}).With("service", service)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// Fatal logs msg at error level and exits.
+func Fatal(msg string, args ...any) {
+	slog.Error(msg, args...)
+	os.Exit(1)
+}
+
+// WithCorrelationID returns a copy of ctx carrying id.
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by ctx, or "" if none.
+func CorrelationID(ctx context.Context) string {
+	id, _ := ctx.Value(correlationKey{}).(string)
+	return id
+}
+
+// EnsureCorrelationID returns ctx unchanged if it already carries a
+// correlation ID and a copy with id, or a fresh one if id is empty,
+// otherwise.
+func EnsureCorrelationID(ctx context.Context, id string) context.Context {
+	if CorrelationID(ctx) != "" {
+		return ctx
+	}
+	if id == "" {
+		id = NewCorrelationID()
+	}
+	return WithCorrelationID(ctx, id)
+}
+
+func NewCorrelationID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func parseLevel(s string) slog.Level {
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(s)); err != nil {
+		return slog.LevelInfo
+	}
+	return level
+}
+
+// correlationHandler adds the correlation ID of the record's context to
+// every record logged through the *Context variants of slog.
+type correlationHandler struct {
+	slog.Handler
+}
+
+func (h *correlationHandler) Handle(ctx context.Context, r slog.Record) error {
+	if id := CorrelationID(ctx); id != "" {
+		r.AddAttrs(slog.String("correlation_id", id))
+	}
+	return h.Handler.Handle(ctx, r)
+}
+
+func (h *correlationHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &correlationHandler{Handler: h.Handler.WithAttrs(attrs)}
+}
+
+func (h *correlationHandler) WithGroup(name string) slog.Handler {
+	return &correlationHandler{Handler: h.Handler.WithGroup(name)}
+}
@@ -0,0 +1,269 @@
+// Package migrate applies versioned SQL migrations that services embed into
+// their binaries.
+//
+// Migrations are read from files named <version>_<name>.up.sql and
+// <version>_<name>.down.sql, e.g. 0001_create_orders.up.sql. Applied versions
+// are tracked in the schema_migrations table.
+//
+// The services' migrations are written for SQLite 3.35 or later, the first
+// version with ALTER TABLE ... DROP COLUMN, which the down migrations of
+// added columns use. Open and RunCommand refuse older versions.
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+}
+
+type Migrator struct {
+	db         *sql.DB
+	migrations []Migration
+}
+
+// New loads every migration found at the root of fsys.
+func New(db *sql.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Up applies all pending migrations in version order, each in its own
+// transaction.
+func (m *Migrator) Up(ctx context.Context) error {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, mg := range pending {
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mg.Up); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO schema_migrations (version, name, applied_at)
+				VALUES (?, ?, CURRENT_TIMESTAMP)
+			`, mg.Version, mg.Name)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to apply migration %04d_%s: %w", mg.Version, mg.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// Down rolls back the given number of most recently applied migrations.
+func (m *Migrator) Down(ctx context.Context, steps int) error {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return err
+	}
+
+	for i := len(m.migrations) - 1; i >= 0 && steps > 0; i-- {
+		mg := m.migrations[i]
+		if !applied[mg.Version] {
+			continue
+		}
+		if mg.Down == "" {
+			return fmt.Errorf("migration %04d_%s has no down migration", mg.Version, mg.Name)
+		}
+
+		err := m.inTx(ctx, func(tx *sql.Tx) error {
+			if _, err := tx.ExecContext(ctx, mg.Down); err != nil {
+				return err
+			}
+			_, err := tx.ExecContext(ctx, `
+				DELETE FROM schema_migrations
+				WHERE version = ?
+			`, mg.Version)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("failed to roll back migration %04d_%s: %w", mg.Version, mg.Name, err)
+		}
+		steps--
+	}
+
+	return nil
+}
+
+// Pending returns the migrations that have not been applied yet.
+func (m *Migrator) Pending(ctx context.Context) ([]Migration, error) {
+	applied, err := m.applied(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending []Migration
+	for _, mg := range m.migrations {
+		if !applied[mg.Version] {
+			pending = append(pending, mg)
+		}
+	}
+	return pending, nil
+}
+
+// PrintPending writes one line per pending migration to w.
+func (m *Migrator) PrintPending(ctx context.Context, w io.Writer) error {
+	pending, err := m.Pending(ctx)
+	if err != nil {
+		return err
+	}
+
+	if len(pending) == 0 {
+		_, err := fmt.Fprintln(w, "No pending migrations")
+		return err
+	}
+
+	for _, mg := range pending {
+		if _, err := fmt.Fprintf(w, "%04d_%s\n", mg.Version, mg.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (m *Migrator) applied(ctx context.Context) (map[int]bool, error) {
+	_, err := m.db.ExecContext(ctx, `
+		CREATE TABLE IF NOT EXISTS schema_migrations (
+			version    INTEGER PRIMARY KEY,
+			name       TEXT NOT NULL,
+			applied_at DATETIME NOT NULL
+		)
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+
+	rows, err := m.db.QueryContext(ctx, `SELECT version FROM schema_migrations`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read schema_migrations: %w", err)
+	}
+	defer rows.Close()
+
+	applied := make(map[int]bool)
+	for rows.Next() {
+		var v int
+		if err := rows.Scan(&v); err != nil {
+			return nil, err
+		}
+		applied[v] = true
+	}
+
+	return applied, rows.Err()
+}
+
+func (m *Migrator) inTx(ctx context.Context, fn func(*sql.Tx) error) error {
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read migrations: %w", err)
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+
+		version, name, direction, err := parseFileName(e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		body, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, fmt.Errorf("failed to read migration %s: %w", e.Name(), err)
+		}
+
+		mg, ok := byVersion[version]
+		if !ok {
+			mg = &Migration{Version: version, Name: name}
+			byVersion[version] = mg
+		}
+		if mg.Name != name {
+			return nil, fmt.Errorf("migration version %04d used for both %s and %s", version, mg.Name, name)
+		}
+
+		if direction == "up" {
+			mg.Up = string(body)
+		} else {
+			mg.Down = string(body)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, mg := range byVersion {
+		if mg.Up == "" {
+			return nil, fmt.Errorf("migration %04d_%s has no up migration", mg.Version, mg.Name)
+		}
+		migrations = append(migrations, *mg)
+	}
+
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+
+	return migrations, nil
+}
+
+// parseFileName splits "0001_create_orders.up.sql" into its version, name
+// and direction.
+func parseFileName(fileName string) (int, string, string, error) {
+	base, ok := strings.CutSuffix(fileName, ".sql")
+	if !ok {
+		return 0, "", "", fmt.Errorf("migration %s is not a .sql file", fileName)
+	}
+
+	var direction string
+	switch {
+	case strings.HasSuffix(base, ".up"):
+		direction = "up"
+	case strings.HasSuffix(base, ".down"):
+		direction = "down"
+	default:
+		return 0, "", "", fmt.Errorf("migration %s must end in .up.sql or .down.sql", fileName)
+	}
+	base = strings.TrimSuffix(base, "."+direction)
+
+	rawVersion, name, ok := strings.Cut(base, "_")
+	if !ok {
+		return 0, "", "", fmt.Errorf("migration %s must be named <version>_<name>", fileName)
+	}
+
+	version, err := strconv.Atoi(rawVersion)
+	if err != nil || version <= 0 {
+		return 0, "", "", fmt.Errorf("migration %s has an invalid version", fileName)
+	}
+
+	return version, name, direction, nil
+}
@@ -0,0 +1,111 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Open opens a database like sql.Open and applies the pending migrations
+// found in dir of fsys, e.g. the embedded migrations directory of a
+// service.
+func Open(driver, dsn string, fsys fs.FS, dir string) (*sql.DB, error) {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	if err := checkVersion(driver, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	m, err := newFromDir(db, fsys, dir)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	if err := m.Up(context.Background()); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return db, nil
+}
+
+// RunCommand prints the pending migrations or rolls back the given number
+// of them, for services to offer as flags without starting up.
+func RunCommand(driver, dsn string, fsys fs.FS, dir string, printPending bool, down int) error {
+	db, err := sql.Open(driver, dsn)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := checkVersion(driver, db); err != nil {
+		return err
+	}
+
+	m, err := newFromDir(db, fsys, dir)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	if down > 0 {
+		if err := m.Down(ctx, down); err != nil {
+			return err
+		}
+	}
+	if printPending {
+		return m.PrintPending(ctx, os.Stdout)
+	}
+	return nil
+}
+
+// minSQLiteVersion is the oldest SQLite that runs the migrations.
+var minSQLiteVersion = [3]int{3, 35, 0}
+
+// checkVersion fails if db is an SQLite database older than
+// minSQLiteVersion. Other drivers are not checked.
+func checkVersion(driver string, db *sql.DB) error {
+	if driver != "sqlite3" {
+		return nil
+	}
+
+	var version string
+	if err := db.QueryRow(`SELECT sqlite_version()`).Scan(&version); err != nil {
+		return fmt.Errorf("failed to read SQLite version: %w", err)
+	}
+
+	var v [3]int
+	for i, part := range strings.SplitN(version, ".", 3) {
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			return fmt.Errorf("unexpected SQLite version %q", version)
+		}
+		v[i] = n
+	}
+
+	if slices.Compare(v[:], minSQLiteVersion[:]) < 0 {
+		return fmt.Errorf("SQLite %s is too old, migrations need %d.%d.%d or later", version, minSQLiteVersion[0], minSQLiteVersion[1], minSQLiteVersion[2])
+	}
+	return nil
+}
+
+func newFromDir(db *sql.DB, fsys fs.FS, dir string) (*Migrator, error) {
+	sub, err := fs.Sub(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+	return New(db, sub)
+}
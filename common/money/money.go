@@ -0,0 +1,161 @@
+// Package money does overflow-checked arithmetic on amounts held in the
+// minor units (e.g. cents) of a single currency.
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+var (
+	ErrCurrencyMismatch = errors.New("money: currency mismatch")
+	ErrOverflow         = errors.New("money: amount overflows int64")
+)
+
+// Rounding selects how fractional minor units are resolved.
+type Rounding int
+
+const (
+	// HalfUp rounds halves away from zero, the usual rule for tax.
+	HalfUp Rounding = iota
+	// HalfEven rounds halves to the nearest even amount.
+	HalfEven
+	// Down truncates towards zero.
+	Down
+)
+
+type Money struct {
+	Currency string
+	Amount   int64
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Currency: currency, Amount: amount}
+}
+
+// Zero returns no money in the given currency.
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+func FromProto(m *pb.Money) Money {
+	if m == nil {
+		return Money{}
+	}
+	return Money{Currency: m.Currency, Amount: m.Amount}
+}
+
+func (m Money) Proto() *pb.Money {
+	return &pb.Money{Currency: m.Currency, Amount: m.Amount}
+}
+
+func (m Money) IsZero() bool {
+	return m.Amount == 0
+}
+
+func (m Money) IsNegative() bool {
+	return m.Amount < 0
+}
+
+func (m Money) String() string {
+	return fmt.Sprintf("%d %s", m.Amount, m.Currency)
+}
+
+func (m Money) Add(o Money) (Money, error) {
+	if err := m.sameCurrency(o); err != nil {
+		return Money{}, err
+	}
+
+	sum := m.Amount + o.Amount
+	if (o.Amount > 0 && sum < m.Amount) || (o.Amount < 0 && sum > m.Amount) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Currency: m.Currency, Amount: sum}, nil
+}
+
+func (m Money) Sub(o Money) (Money, error) {
+	if o.Amount == math.MinInt64 {
+		return Money{}, ErrOverflow
+	}
+	return m.Add(Money{Currency: o.Currency, Amount: -o.Amount})
+}
+
+// Mul multiplies m by an integer factor such as a quantity.
+func (m Money) Mul(n int64) (Money, error) {
+	if m.Amount == 0 || n == 0 {
+		return Money{Currency: m.Currency}, nil
+	}
+
+	product := m.Amount * n
+	if product/n != m.Amount || (n == -1 && m.Amount == math.MinInt64) {
+		return Money{}, ErrOverflow
+	}
+	return Money{Currency: m.Currency, Amount: product}, nil
+}
+
+// Scale multiplies m by num/den, rounding the result with r. It is used
+// for percentages, e.g. Scale(825, 10000, HalfUp) for 8.25% tax.
+func (m Money) Scale(num, den int64, r Rounding) (Money, error) {
+	if den == 0 {
+		return Money{}, errors.New("money: division by zero")
+	}
+
+	x := new(big.Rat).SetFrac(
+		new(big.Int).Mul(big.NewInt(m.Amount), big.NewInt(num)),
+		big.NewInt(den),
+	)
+
+	amount, err := round(x, r)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Currency: m.Currency, Amount: amount}, nil
+}
+
+// Sum adds up amounts that must all be in currency.
+func Sum(currency string, amounts ...Money) (Money, error) {
+	total := Zero(currency)
+	for _, a := range amounts {
+		var err error
+		if total, err = total.Add(a); err != nil {
+			return Money{}, err
+		}
+	}
+	return total, nil
+}
+
+func (m Money) sameCurrency(o Money) error {
+	if m.Currency != o.Currency {
+		return fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, o.Currency)
+	}
+	return nil
+}
+
+func round(x *big.Rat, r Rounding) (int64, error) {
+	num, den := x.Num(), x.Denom()
+
+	q, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	if rem.Sign() != 0 && r != Down {
+		// Compare twice the remainder with the denominator to tell whether
+		// the fraction is below, at or above one half.
+		twice := new(big.Int).Abs(rem)
+		twice.Lsh(twice, 1)
+		cmp := twice.Cmp(den)
+
+		awayFromZero := cmp > 0 ||
+			(cmp == 0 && r == HalfUp) ||
+			(cmp == 0 && r == HalfEven && q.Bit(0) == 1)
+		if awayFromZero {
+			q.Add(q, big.NewInt(int64(num.Sign())))
+		}
+	}
+
+	if !q.IsInt64() {
+		return 0, ErrOverflow
+	}
+	return q.Int64(), nil
+}
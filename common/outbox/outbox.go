@@ -0,0 +1,181 @@
+// Package outbox relays events to Kafka that services persist in the same
+// transaction as the state change they describe.
+//
+// Messages live in an outbox table:
+//
+//	CREATE TABLE outbox (
+//	    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+//	    topic          TEXT NOT NULL,
+//	    msg_key        TEXT NOT NULL,
+//	    payload        BLOB NOT NULL,
+//	    attempts       INTEGER NOT NULL DEFAULT 0,
+//	    last_error     TEXT,
+//	    correlation_id TEXT NOT NULL DEFAULT '',
+//	    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+//	    published_at   DATETIME
+//	);
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/kiriyms/oms_go-common/logging"
+)
+
+// Message is an event waiting in the outbox to be relayed.
+type Message struct {
+	ID            int64
+	Topic         string
+	Key           string
+	Payload       []byte
+	Attempts      int
+	CorrelationID string
+}
+
+type Store interface {
+	FetchOutbox(ctx context.Context, limit int) ([]Message, error)
+	MarkOutboxPublished(ctx context.Context, id int64) error
+	MarkOutboxFailed(ctx context.Context, id int64, cause error) error
+}
+
+// Publisher writes a message to Kafka.
+type Publisher interface {
+	Publish(ctx context.Context, topic string, key string, value []byte) error
+}
+
+const (
+	batchSize  = 100
+	maxBackoff = time.Minute
+)
+
+// Relay drains the outbox table into Kafka. Messages are published in
+// insertion order and only marked as published once Kafka acknowledged them,
+// so delivery is at-least-once and consumers must tolerate duplicates.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	interval  time.Duration
+}
+
+func NewRelay(store Store, publisher Publisher, interval time.Duration) *Relay {
+	return &Relay{store: store, publisher: publisher, interval: interval}
+}
+
+func (r *Relay) Start(ctx context.Context) {
+	slog.Info("starting outbox relay")
+	backoff := r.interval
+
+	for {
+		wait := r.interval
+		if err := r.relay(ctx); err != nil {
+			slog.Error("outbox relay failed", "retry_in", backoff, "error", err)
+			wait = backoff
+			backoff = min(backoff*2, maxBackoff)
+		} else {
+			backoff = r.interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(wait):
+		}
+	}
+}
+
+// relay publishes one batch and stops at the first failure so that events
+// for the same order never overtake each other.
+func (r *Relay) relay(ctx context.Context) error {
+	msgs, err := r.store.FetchOutbox(ctx, batchSize)
+	if err != nil {
+		return err
+	}
+
+	for _, msg := range msgs {
+		msgCtx := logging.WithCorrelationID(ctx, msg.CorrelationID)
+		if err := r.publisher.Publish(msgCtx, msg.Topic, msg.Key, msg.Payload); err != nil {
+			if mErr := r.store.MarkOutboxFailed(ctx, msg.ID, err); mErr != nil {
+				slog.ErrorContext(msgCtx, "failed to record outbox failure", "outbox_id", msg.ID, "error", mErr)
+			}
+			return err
+		}
+
+		if err := r.store.MarkOutboxPublished(ctx, msg.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Insert enqueues msg as part of tx, tagged with the correlation ID of ctx.
+func Insert(ctx context.Context, tx *sql.Tx, msg Message) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO outbox (topic, msg_key, payload, attempts, correlation_id, created_at)
+		VALUES (?, ?, ?, 0, ?, CURRENT_TIMESTAMP)
+	`, msg.Topic, msg.Key, msg.Payload, logging.CorrelationID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed to enqueue %s event: %w", msg.Topic, err)
+	}
+	return nil
+}
+
+// Fetch returns up to limit unpublished messages, oldest first.
+func Fetch(ctx context.Context, db *sql.DB, limit int) ([]Message, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT id, topic, msg_key, payload, attempts, correlation_id
+		FROM outbox
+		WHERE published_at IS NULL
+		ORDER BY id ASC
+		LIMIT ?
+	`, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch outbox: %w", err)
+	}
+	defer rows.Close()
+
+	var msgs []Message
+	for rows.Next() {
+		var m Message
+		if err := rows.Scan(&m.ID, &m.Topic, &m.Key, &m.Payload, &m.Attempts, &m.CorrelationID); err != nil {
+			return nil, fmt.Errorf("failed to scan outbox message: %w", err)
+		}
+		msgs = append(msgs, m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return msgs, nil
+}
+
+func MarkPublished(ctx context.Context, db *sql.DB, id int64) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE outbox
+		SET published_at = CURRENT_TIMESTAMP,
+		    attempts = attempts + 1
+		WHERE id = ?
+	`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message %d published: %w", id, err)
+	}
+	return nil
+}
+
+func MarkFailed(ctx context.Context, db *sql.DB, id int64, cause error) error {
+	_, err := db.ExecContext(ctx, `
+		UPDATE outbox
+		SET attempts = attempts + 1,
+		    last_error = ?
+		WHERE id = ?
+	`, cause.Error(), id)
+	if err != nil {
+		return fmt.Errorf("failed to mark outbox message %d failed: %w", id, err)
+	}
+	return nil
+}
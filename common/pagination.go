@@ -0,0 +1,46 @@
+package common
+
+import (
+	"encoding/base64"
+	"encoding/json"
+)
+
+const (
+	DefaultPageSize = 50
+	MaxPageSize     = 200
+)
+
+// PageSize returns the requested page size, falling back to DefaultPageSize
+// when none was given and capping it at MaxPageSize.
+func PageSize(requested int32) (int, error) {
+	switch {
+	case requested < 0:
+		return 0, InvalidArgument("page size", "cannot be negative")
+	case requested == 0:
+		return DefaultPageSize, nil
+	case requested > MaxPageSize:
+		return MaxPageSize, nil
+	}
+	return int(requested), nil
+}
+
+// EncodePageToken serializes a cursor into an opaque page token.
+func EncodePageToken(cursor any) (string, error) {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodePageToken reads a cursor written by EncodePageToken.
+func DecodePageToken(token string, cursor any) error {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return InvalidArgument("page token", "is malformed")
+	}
+	if err := json.Unmarshal(b, cursor); err != nil {
+		return InvalidArgument("page token", "is malformed")
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package main
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	common "github.com/kiriyms/oms_go-common"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type errorResponse struct {
+	Error errorBody `json:"error"`
+}
+
+type errorBody struct {
+	Code     string                 `json:"code"`
+	Message  string                 `json:"message"`
+	Resource *errorResource         `json:"resource,omitempty"`
+	Field    string                 `json:"field,omitempty"`
+	Items    []common.StockShortage `json:"items,omitempty"`
+}
+
+type errorResource struct {
+	Type string `json:"type"`
+	ID   string `json:"id,omitempty"`
+}
+
+// writeError is the single place where errors, whether raised by the
+// gateway itself or returned by a backend service, become HTTP responses.
+// Server errors are logged with the request's correlation ID and answered
+// with a generic message, since their details may expose internals such as
+// SQL.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	err = common.FromStatus(err)
+
+	var (
+		notFound *common.NotFoundError
+		conflict *common.ConflictError
+		shortage *common.InsufficientStockError
+		invalid  *common.InvalidArgumentError
+	)
+
+	switch {
+	case errors.As(err, &notFound):
+		common.WriteJSON(w, http.StatusNotFound, errorResponse{errorBody{
+			Code:     common.ReasonNotFound,
+			Message:  notFound.Error(),
+			Resource: &errorResource{Type: notFound.Resource, ID: notFound.ID},
+		}})
+	case errors.As(err, &conflict):
+		common.WriteJSON(w, http.StatusConflict, errorResponse{errorBody{
+			Code:     common.ReasonConflict,
+			Message:  conflict.Error(),
+			Resource: &errorResource{Type: conflict.Resource, ID: conflict.ID},
+		}})
+	case errors.As(err, &shortage):
+		common.WriteJSON(w, http.StatusConflict, errorResponse{errorBody{
+			Code:    common.ReasonInsufficientStock,
+			Message: shortage.Error(),
+			Items:   shortage.Items,
+		}})
+	case errors.As(err, &invalid):
+		common.WriteJSON(w, http.StatusBadRequest, errorResponse{errorBody{
+			Code:    common.ReasonInvalidArgument,
+			Message: invalid.Error(),
+			Field:   invalid.Field,
+		}})
+	default:
+		st := status.Convert(err)
+		code := httpStatus(st.Code())
+		msg := st.Message()
+		if code >= http.StatusInternalServerError {
+			slog.ErrorContext(r.Context(), "request failed", "method", r.Method, "path", r.URL.Path, "code", st.Code(), "error", msg)
+			msg = http.StatusText(code)
+		}
+		common.WriteJSON(w, code, errorResponse{errorBody{
+			Code:    codeName(st.Code()),
+			Message: msg,
+		}})
+	}
+}
+
+// httpStatus maps gRPC codes without a domain error attached to HTTP.
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.AlreadyExists, codes.Aborted, codes.FailedPrecondition:
+		return http.StatusConflict
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Canceled:
+		return 499
+	case codes.Unimplemented:
+		return http.StatusNotImplemented
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// codeName turns e.g. FailedPrecondition into FAILED_PRECONDITION to match
+// the casing of the domain reasons.
+func codeName(code codes.Code) string {
+	var b strings.Builder
+	for i, r := range code.String() {
+		if i > 0 && r >= 'A' && r <= 'Z' {
+			b.WriteByte('_')
+		}
+		b.WriteRune(r)
+	}
+	return strings.ToUpper(b.String())
+}
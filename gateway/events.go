@@ -0,0 +1,137 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// sseHeartbeat is how often a comment is sent on an idle event stream, so
+// that proxies do not close it.
+const sseHeartbeat = 15 * time.Second
+
+// HandleOrderEvents streams the status changes of an order as Server-Sent
+// Events. Every event carries its sequence as ID, so a reconnecting
+// EventSource resumes where it left off through the Last-Event-ID header.
+func (h *handler) HandleOrderEvents(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("orderID")
+
+	var after int64
+	if id := r.Header.Get("Last-Event-ID"); id != "" {
+		var err error
+		if after, err = strconv.ParseInt(id, 10, 64); err != nil || after < 0 {
+			writeError(w, r, common.InvalidArgument("Last-Event-ID", "must be a non-negative integer"))
+			return
+		}
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, r, errors.New("streaming is not supported"))
+		return
+	}
+
+	stream, err := h.client.WatchOrder(r.Context(), &pb.WatchOrderRequest{
+		OrderID:       orderID,
+		AfterSequence: after,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	// The order service sends its headers once the watch is set up, or ends
+	// the stream without any if it failed, e.g. for an unknown order.
+	md, err := stream.Header()
+	if err == nil && md == nil {
+		_, err = stream.Recv()
+	}
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	events := make(chan *pb.OrderStatusEvent)
+	errc := make(chan error, 1)
+	go func() {
+		for {
+			e, err := stream.Recv()
+			if err != nil {
+				errc <- err
+				return
+			}
+			select {
+			case events <- e:
+			case <-r.Context().Done():
+				return
+			}
+		}
+	}()
+
+	heartbeat := time.NewTicker(sseHeartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case <-heartbeat.C:
+			fmt.Fprint(w, ": heartbeat\n\n")
+		case e := <-events:
+			if err := writeStatusEvent(w, e); err != nil {
+				slog.WarnContext(r.Context(), "failed to write order event", "order_id", orderID, "error", err)
+				return
+			}
+		case err := <-errc:
+			if !errors.Is(err, io.EOF) {
+				slog.WarnContext(r.Context(), "order event stream failed", "order_id", orderID, "error", err)
+				writeSSE(w, "", "error", []byte(strconv.Quote(common.FromStatus(err).Error())))
+			}
+			flusher.Flush()
+			return
+		}
+		flusher.Flush()
+	}
+}
+
+func writeStatusEvent(w io.Writer, e *pb.OrderStatusEvent) error {
+	// protojson spells statuses by name rather than number and puts the
+	// timestamp in RFC 3339, which suits browsers better.
+	data, err := protojson.MarshalOptions{EmitUnpopulated: true}.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	// An SSE data line must not contain newlines.
+	data, err = json.Marshal(json.RawMessage(data))
+	if err != nil {
+		return err
+	}
+
+	return writeSSE(w, strconv.FormatInt(e.Sequence, 10), "status", data)
+}
+
+func writeSSE(w io.Writer, id, event string, data []byte) error {
+	if id != "" {
+		if _, err := fmt.Fprintf(w, "id: %s\n", id); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
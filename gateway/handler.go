@@ -1,62 +1,126 @@
 package main
 
 import (
-	"errors"
 	"fmt"
 	"net/http"
 
 	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
 type handler struct {
-	client pb.OrderServiceClient
+	client        pb.OrderServiceClient
+	stockClient   pb.StockServiceClient
+	paymentClient pb.PaymentServiceClient
+	kitchenClient pb.KitchenServiceClient
 }
 
-func NewHandler(client pb.OrderServiceClient) *handler {
+func NewHandler(client pb.OrderServiceClient, stockClient pb.StockServiceClient, paymentClient pb.PaymentServiceClient, kitchenClient pb.KitchenServiceClient) *handler {
 	return &handler{
-		client: client,
+		client:        client,
+		stockClient:   stockClient,
+		paymentClient: paymentClient,
+		kitchenClient: kitchenClient,
 	}
 }
 
 func (h *handler) registerRoutes(mux *http.ServeMux) {
 	mux.HandleFunc("POST /api/customers/{customerID}/order", h.HandleCreateOrder)
+	mux.HandleFunc("POST /api/customers/{customerID}/quote", h.HandleQuoteOrder)
 	mux.HandleFunc("GET /api/orders/{orderID}", h.HandleGetOrder)
 	mux.HandleFunc("GET /api/customers/{customerID}/orders", h.HandleGetUserOrders)
+	mux.HandleFunc("POST /api/orders/{orderID}/cancel", h.HandleCancelOrder)
+	mux.HandleFunc("GET /api/orders/{orderID}/events", h.HandleOrderEvents)
+	mux.HandleFunc("GET /api/orders/{orderID}/payment", h.HandleGetOrderPayment)
+	mux.HandleFunc("POST /api/orders/{orderID}/payment/capture", h.HandleCaptureOrderPayment)
+
+	mux.HandleFunc("GET /api/stock", h.HandleListStockItems)
+	mux.HandleFunc("POST /api/stock", h.HandleUpsertStockItem)
+	mux.HandleFunc("GET /api/stock/{itemID}", h.HandleGetStockItem)
+	mux.HandleFunc("PATCH /api/stock/{itemID}/quantity", h.HandleAdjustStockQuantity)
+	mux.HandleFunc("DELETE /api/stock/{itemID}", h.HandleRemoveStockItem)
+
+	mux.HandleFunc("GET /api/kitchen/stations/{station}/tickets", h.HandleListTickets)
+	mux.HandleFunc("POST /api/kitchen/tickets/{ticketID}/start", h.HandleStartTicket)
+	mux.HandleFunc("POST /api/kitchen/tickets/{ticketID}/bump", h.HandleBumpTicket)
+	mux.HandleFunc("POST /api/kitchen/tickets/{ticketID}/recall", h.HandleRecallTicket)
+	mux.HandleFunc("POST /api/kitchen/tickets/{ticketID}/void", h.HandleVoidTicket)
 }
 
+// HandleCreateOrder places an order for the items in the body. An optional
+// promo_code query parameter applies a discount, and the vip and rush query
+// parameters flag the order for the kitchen. Clients should send an
+// Idempotency-Key header so that retrying a request that timed out returns
+// the original order rather than placing a second one.
 func (h *handler) HandleCreateOrder(w http.ResponseWriter, r *http.Request) {
 	cID := r.PathValue("customerID")
 
 	var items []*pb.ItemWithQuantity
 	if err := common.ReadJSON(r, &items); err != nil {
-		common.WriteError(w, http.StatusBadRequest, fmt.Sprintf("Invalid request payload: %v", err))
+		writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
 		return
 	}
 
 	if err := validateItems(items); err != nil {
-		common.WriteError(w, http.StatusBadRequest, err.Error())
+		writeError(w, r, err)
+		return
+	}
+
+	vip, err := queryBool(r, "vip")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	rush, err := queryBool(r, "rush")
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	o, err := h.client.CreateOrder(r.Context(), &pb.CreateOrderRequest{
+		CustomerID:     cID,
+		Items:          items,
+		PromoCode:      r.URL.Query().Get("promo_code"),
+		IdempotencyKey: r.Header.Get("Idempotency-Key"),
+		VIP:            vip,
+		Rush:           rush,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusCreated, o)
+}
+
+// HandleQuoteOrder prices the items in the body the way HandleCreateOrder
+// would, without placing an order. It accepts the same promo_code.
+func (h *handler) HandleQuoteOrder(w http.ResponseWriter, r *http.Request) {
+	cID := r.PathValue("customerID")
+
+	var items []*pb.ItemWithQuantity
+	if err := common.ReadJSON(r, &items); err != nil {
+		writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
+		return
+	}
+
+	if err := validateItems(items); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	o, err := h.client.QuoteOrder(r.Context(), &pb.CreateOrderRequest{
 		CustomerID: cID,
 		Items:      items,
+		PromoCode:  r.URL.Query().Get("promo_code"),
 	})
-	rStatus := status.Convert(err)
-	if rStatus != nil {
-		if rStatus.Code() != codes.InvalidArgument {
-			common.WriteError(w, http.StatusBadRequest, rStatus.Message())
-			return
-		}
-
-		common.WriteError(w, http.StatusInternalServerError, err.Error())
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	common.WriteJSON(w, http.StatusCreated, o)
+	common.WriteJSON(w, http.StatusOK, o)
 }
 
 func (h *handler) HandleGetOrder(w http.ResponseWriter, r *http.Request) {
@@ -65,34 +129,79 @@ func (h *handler) HandleGetOrder(w http.ResponseWriter, r *http.Request) {
 	o, err := h.client.GetOrder(r.Context(), &pb.GetOrderRequest{
 		ID: orderID,
 	})
-	rStatus := status.Convert(err)
-	if rStatus != nil {
-		if rStatus.Code() != codes.InvalidArgument {
-			common.WriteError(w, http.StatusBadRequest, rStatus.Message())
-			return
-		}
-
-		common.WriteError(w, http.StatusInternalServerError, err.Error())
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	common.WriteJSON(w, http.StatusOK, o)
 }
 
+// HandleGetUserOrders lists a customer's orders, newest first. It accepts
+// the page_size, page_token, status (comma separated), created_after and
+// created_before (RFC 3339) query parameters.
 func (h *handler) HandleGetUserOrders(w http.ResponseWriter, r *http.Request) {
 	customerID := r.PathValue("customerID")
 
+	pageSize, err := queryInt32(r, "page_size")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	statuses, err := queryOrderStatuses(r, "status")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	createdAfter, err := queryTime(r, "created_after")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	createdBefore, err := queryTime(r, "created_before")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
 	o, err := h.client.GetUserOrders(r.Context(), &pb.GetUserOrdersRequest{
-		CustomerID: customerID,
+		CustomerID:    customerID,
+		PageSize:      pageSize,
+		PageToken:     r.URL.Query().Get("page_token"),
+		Statuses:      statuses,
+		CreatedAfter:  createdAfter,
+		CreatedBefore: createdBefore,
 	})
-	rStatus := status.Convert(err)
-	if rStatus != nil {
-		if rStatus.Code() != codes.InvalidArgument {
-			common.WriteError(w, http.StatusBadRequest, rStatus.Message())
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, o)
+}
+
+func (h *handler) HandleCancelOrder(w http.ResponseWriter, r *http.Request) {
+	orderID := r.PathValue("orderID")
+
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := common.ReadJSON(r, &req); err != nil {
+			writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
 			return
 		}
+	}
 
-		common.WriteError(w, http.StatusInternalServerError, err.Error())
+	o, err := h.client.CancelOrder(r.Context(), &pb.CancelOrderRequest{
+		OrderID: orderID,
+		Reason:  req.Reason,
+	})
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
@@ -106,10 +215,10 @@ func validateItems(items []*pb.ItemWithQuantity) error {
 
 	for _, item := range items {
 		if item.ID == "" {
-			return errors.New("item ID cannot be empty")
+			return common.InvalidArgument("item ID", "cannot be empty")
 		}
 		if item.Quantity <= 0 {
-			return errors.New("item quantity must be greater than zero")
+			return common.InvalidArgument("item quantity", "must be greater than zero")
 		}
 	}
 
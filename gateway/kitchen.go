@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// HandleListTickets lists a station's queued and in progress tickets for
+// the kitchen display, oldest first.
+func (h *handler) HandleListTickets(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.kitchenClient.ListTickets(r.Context(), &pb.ListTicketsRequest{
+		Station: r.PathValue("station"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *handler) HandleStartTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := h.kitchenClient.StartTicket(r.Context(), &pb.StartTicketRequest{
+		TicketID: r.PathValue("ticketID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, t)
+}
+
+func (h *handler) HandleBumpTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := h.kitchenClient.BumpTicket(r.Context(), &pb.BumpTicketRequest{
+		TicketID: r.PathValue("ticketID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, t)
+}
+
+func (h *handler) HandleRecallTicket(w http.ResponseWriter, r *http.Request) {
+	t, err := h.kitchenClient.RecallTicket(r.Context(), &pb.RecallTicketRequest{
+		TicketID: r.PathValue("ticketID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, t)
+}
+
+// HandleVoidTicket gives up on a ticket, which fails and refunds its order.
+// The body may carry a reason.
+func (h *handler) HandleVoidTicket(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Reason string `json:"reason"`
+	}
+	if r.ContentLength != 0 {
+		if err := common.ReadJSON(r, &req); err != nil {
+			writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
+			return
+		}
+	}
+
+	t, err := h.kitchenClient.VoidTicket(r.Context(), &pb.VoidTicketRequest{
+		TicketID: r.PathValue("ticketID"),
+		Reason:   req.Reason,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, t)
+}
@@ -1,39 +1,88 @@
 package main
 
 import (
-	"log"
+	"log/slog"
 	"net/http"
 
 	_ "github.com/joho/godotenv/autoload"
 	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
-	httpAddr         = common.GetEnv("HTTP_ADDR", ":8080")
-	orderServiceAddr = common.GetEnv("ORDER_SERVICE_ADDR", "localhost:50051")
+	httpAddr           = common.GetEnv("HTTP_ADDR", ":8080")
+	orderServiceAddr   = common.GetEnv("ORDER_SERVICE_ADDR", "localhost:50051")
+	stockServiceAddr   = common.GetEnv("STOCK_SERVICE_ADDR", "localhost:50052")
+	paymentServiceAddr = common.GetEnv("PAYMENT_SERVICE_ADDR", "localhost:50053")
+	kitchenServiceAddr = common.GetEnv("KITCHEN_SERVICE_ADDR", "localhost:50054")
 )
 
 func main() {
-	conn, err := grpc.NewClient(orderServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	logging.Init("gateway")
+
+	conn, err := grpc.NewClient(orderServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
 	if err != nil {
-		log.Fatalf("Failed to connect to Order Service: %v", err)
+		logging.Fatal("failed to connect to order service", "error", err)
 	}
 	defer conn.Close()
 
-	log.Println("Dialed Order Service at ", orderServiceAddr)
+	slog.Info("dialed order service", "addr", orderServiceAddr)
+
+	stockConn, err := grpc.NewClient(stockServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal("failed to connect to stock service", "error", err)
+	}
+	defer stockConn.Close()
+
+	slog.Info("dialed stock service", "addr", stockServiceAddr)
+
+	paymentConn, err := grpc.NewClient(paymentServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal("failed to connect to payment service", "error", err)
+	}
+	defer paymentConn.Close()
+
+	slog.Info("dialed payment service", "addr", paymentServiceAddr)
+
+	kitchenConn, err := grpc.NewClient(kitchenServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal("failed to connect to kitchen service", "error", err)
+	}
+	defer kitchenConn.Close()
+
+	slog.Info("dialed kitchen service", "addr", kitchenServiceAddr)
 
 	c := pb.NewOrderServiceClient(conn)
+	stockClient := pb.NewStockServiceClient(stockConn)
+	paymentClient := pb.NewPaymentServiceClient(paymentConn)
+	kitchenClient := pb.NewKitchenServiceClient(kitchenConn)
 
 	mux := http.NewServeMux()
-	handler := NewHandler(c)
+	handler := NewHandler(c, stockClient, paymentClient, kitchenClient)
 	handler.registerRoutes(mux)
 
-	log.Printf("Starting Server at %s", httpAddr)
+	slog.Info("http server listening", "addr", httpAddr)
 
-	if err := http.ListenAndServe(httpAddr, mux); err != nil {
-		log.Fatal("Failed to start Gateway HTTP server")
+	if err := http.ListenAndServe(httpAddr, logging.Middleware(mux)); err != nil {
+		logging.Fatal("http server stopped", "error", err)
 	}
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"net/http"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// HandleGetOrderPayment returns the payment opened for an order once its
+// stock is booked.
+func (h *handler) HandleGetOrderPayment(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.paymentClient.GetPayment(r.Context(), &pb.GetPaymentRequest{
+		OrderID: r.PathValue("orderID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp.Payment)
+}
+
+// HandleCaptureOrderPayment pays for an order by capturing its payment. The
+// order moves on to the kitchen once the order service sees the capture.
+func (h *handler) HandleCaptureOrderPayment(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.paymentClient.GetPayment(r.Context(), &pb.GetPaymentRequest{
+		OrderID: r.PathValue("orderID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	captured, err := h.paymentClient.CapturePayment(r.Context(), &pb.CapturePaymentRequest{
+		PaymentID: resp.Payment.ID,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, captured.Payment)
+}
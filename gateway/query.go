@@ -0,0 +1,68 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func queryInt32(r *http.Request, name string) (int32, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, nil
+	}
+
+	v, err := strconv.ParseInt(raw, 10, 32)
+	if err != nil {
+		return 0, common.InvalidArgument(name, "must be an integer")
+	}
+	return int32(v), nil
+}
+
+func queryBool(r *http.Request, name string) (bool, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return false, nil
+	}
+
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		return false, common.InvalidArgument(name, "must be true or false")
+	}
+	return v, nil
+}
+
+func queryTime(r *http.Request, name string) (*timestamppb.Timestamp, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	t, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return nil, common.InvalidArgument(name, "must be an RFC 3339 timestamp")
+	}
+	return timestamppb.New(t), nil
+}
+
+func queryOrderStatuses(r *http.Request, name string) ([]pb.OrderStatus, error) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return nil, nil
+	}
+
+	var statuses []pb.OrderStatus
+	for _, s := range strings.Split(raw, ",") {
+		v, ok := pb.OrderStatus_value[strings.ToUpper(strings.TrimSpace(s))]
+		if !ok || v == int32(pb.OrderStatus_UNKNOWN) {
+			return nil, common.InvalidArgument(name, "contains unknown status "+s)
+		}
+		statuses = append(statuses, pb.OrderStatus(v))
+	}
+	return statuses, nil
+}
@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// stockOrders maps the order_by query parameter to the listing order.
+var stockOrders = map[string]pb.StockItemOrder{
+	"":          pb.StockItemOrder_STOCK_ORDER_ID,
+	"id":        pb.StockItemOrder_STOCK_ORDER_ID,
+	"name":      pb.StockItemOrder_STOCK_ORDER_NAME,
+	"available": pb.StockItemOrder_STOCK_ORDER_AVAILABLE,
+}
+
+// HandleListStockItems lists the catalog. It accepts the page_size,
+// page_token, q (name search), in_stock and order_by query parameters.
+func (h *handler) HandleListStockItems(w http.ResponseWriter, r *http.Request) {
+	pageSize, err := queryInt32(r, "page_size")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	inStock, err := queryBool(r, "in_stock")
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	orderBy, ok := stockOrders[r.URL.Query().Get("order_by")]
+	if !ok {
+		writeError(w, r, common.InvalidArgument("order_by", "must be one of id, name, available"))
+		return
+	}
+
+	resp, err := h.stockClient.ListStockItems(r.Context(), &pb.ListStockItemsRequest{
+		PageSize:    pageSize,
+		PageToken:   r.URL.Query().Get("page_token"),
+		NameQuery:   r.URL.Query().Get("q"),
+		InStockOnly: inStock,
+		OrderBy:     orderBy,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp)
+}
+
+func (h *handler) HandleGetStockItem(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.stockClient.GetStockItem(r.Context(), &pb.GetStockItemRequest{
+		ID: r.PathValue("itemID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp.Item)
+}
+
+// HandleUpsertStockItem creates a stock item, or restocks an existing one by
+// the given quantity and replaces its details.
+func (h *handler) HandleUpsertStockItem(w http.ResponseWriter, r *http.Request) {
+	var req pb.AddStockItemRequest
+	if err := common.ReadJSON(r, &req); err != nil {
+		writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
+		return
+	}
+
+	if err := validateStockItem(&req); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	resp, err := h.stockClient.AddStockItem(r.Context(), &req)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp.Item)
+}
+
+func (h *handler) HandleAdjustStockQuantity(w http.ResponseWriter, r *http.Request) {
+	var req struct {
+		Delta int32 `json:"delta"`
+	}
+	if err := common.ReadJSON(r, &req); err != nil {
+		writeError(w, r, common.InvalidArgument("body", fmt.Sprintf("is not valid JSON: %v", err)))
+		return
+	}
+
+	if req.Delta == 0 {
+		writeError(w, r, common.InvalidArgument("delta", "cannot be zero"))
+		return
+	}
+
+	resp, err := h.stockClient.AdjustStockQuantity(r.Context(), &pb.AdjustStockQuantityRequest{
+		ID:    r.PathValue("itemID"),
+		Delta: req.Delta,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp.Item)
+}
+
+func (h *handler) HandleRemoveStockItem(w http.ResponseWriter, r *http.Request) {
+	resp, err := h.stockClient.RemoveStockItem(r.Context(), &pb.RemoveStockItemRequest{
+		ID: r.PathValue("itemID"),
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	common.WriteJSON(w, http.StatusOK, resp.Item)
+}
+
+func validateStockItem(item *pb.AddStockItemRequest) error {
+	if item.ID == "" {
+		return common.InvalidArgument("item ID", "cannot be empty")
+	}
+	if item.Name == "" {
+		return common.InvalidArgument("item name", "cannot be empty")
+	}
+	if item.Quantity < 0 {
+		return common.InvalidArgument("item quantity", "cannot be negative")
+	}
+	if item.Price != nil {
+		if len(item.Price.Currency) != 3 {
+			return common.InvalidArgument("item price currency", "must be a three-letter ISO 4217 code")
+		}
+		if item.Price.Amount < 0 {
+			return common.InvalidArgument("item price amount", "cannot be negative")
+		}
+	}
+
+	return nil
+}
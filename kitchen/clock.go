@@ -0,0 +1,15 @@
+package main
+
+import "time"
+
+// Clock tells the time. The kitchen's scheduling and estimates take it as a
+// dependency so that they can be driven by a fake clock.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
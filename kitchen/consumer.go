@@ -3,63 +3,275 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"errors"
+	"log/slog"
+	"sync"
 	"time"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
 	"github.com/segmentio/kafka-go"
 )
 
+// Consumer prepares the orders read from orders.created on a fixed number
+// of workers. Messages wait in a bounded queue; when it is full the consumer
+// stops fetching until a worker frees up, so a burst of orders is absorbed
+// by Kafka rather than by the kitchen's memory.
+//
+// Failing steps are retried with exponential backoff. Messages that cannot
+// be decoded, and orders that still fail after the last retry, are moved to
+// orders.created.dlq so that they neither hold up the partition nor get
+// lost.
 type Consumer struct {
-	reader  *kafka.Reader
-	service KitchenService
+	reader   *kafka.Reader
+	producer *Producer
+	service  KitchenService
+	retry    RetryPolicy
+	workers  int
+	queue    chan kafka.Message
+	offsets  *offsetTracker
 }
 
-func NewConsumer(brokerURL string, groupID string, service KitchenService) *Consumer {
+func NewConsumer(brokerURL string, groupID string, producer *Producer, service KitchenService, retry RetryPolicy, workers int, queueSize int) *Consumer {
 	reader := kafka.NewReader(kafka.ReaderConfig{
 		Brokers:  []string{brokerURL},
-		Topic:    "orders.created",
+		Topic:    TopicOrderCreated,
 		GroupID:  groupID,
 		MinBytes: 10e3,
 		MaxBytes: 10e6,
 	})
 
-	return &Consumer{reader: reader, service: service}
+	return &Consumer{
+		reader:   reader,
+		producer: producer,
+		service:  service,
+		retry:    retry,
+		workers:  workers,
+		queue:    make(chan kafka.Message, queueSize),
+		offsets:  newOffsetTracker(),
+	}
 }
 
+// Start fetches messages and hands them to the workers until ctx is
+// canceled. Offsets are committed by the workers once an order's tickets
+// are queued, so orders being taken in during a crash are delivered again.
 func (c *Consumer) Start(ctx context.Context) {
-	log.Printf("Starting consumer...")
+	slog.Info("starting consumer", "topic", TopicOrderCreated, "workers", c.workers, "queue_size", cap(c.queue))
+
+	var wg sync.WaitGroup
+	for range c.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			c.work(ctx)
+		}()
+	}
+	defer wg.Wait()
+	defer close(c.queue)
+
 	for {
-		msg, err := c.reader.ReadMessage(ctx)
+		msg, err := c.reader.FetchMessage(ctx)
 		if err != nil {
-			log.Printf("error reading message: %v (retrying in 5s)", err)
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to read message, retrying in 5s", "error", err)
 			time.Sleep(5 * time.Second)
 			continue
 		}
 
-		var event pb.Order
-		if err := json.Unmarshal(msg.Value, &event); err != nil {
-			log.Printf("failed to unmarshal event: %v", err)
+		c.offsets.track(msg)
+
+		select {
+		case c.queue <- msg:
 			continue
+		default:
 		}
 
-		go func() {
-			log.Printf("Received order %s", event.ID)
-			if err := c.service.AcceptOrder(ctx, &event); err != nil {
-				log.Printf("failed to accept order: %v", err)
-			}
+		slog.Warn("kitchen is saturated, pausing consumption", "workers", c.workers, "queue_size", cap(c.queue))
+		select {
+		case c.queue <- msg:
+			slog.Info("resuming consumption")
+		case <-ctx.Done():
+			return
+		}
+	}
+}
 
-			if err := c.service.ProcessOrder(ctx, &event); err != nil {
-				log.Printf("failed to process order: %v", err)
-			}
+func (c *Consumer) work(ctx context.Context) {
+	for msg := range c.queue {
+		msgCtx := messageContext(ctx, msg)
+		if !c.prepare(msgCtx, msg) {
+			// Only happens on shutdown. The offset and the partition's
+			// later ones are delivered again after a restart.
+			continue
+		}
 
-			if err := c.service.FinishOrder(ctx, event.ID); err != nil {
-				log.Printf("failed to finish order: %v", err)
-			}
-		}()
+		err := c.offsets.complete(msg, func(m kafka.Message) error {
+			return c.reader.CommitMessages(ctx, m)
+		})
+		if err != nil {
+			slog.ErrorContext(msgCtx, "failed to commit message", "offset", msg.Offset, "error", err)
+		}
+	}
+}
+
+// prepare takes an order into the kitchen. It reports whether the message
+// is done with: queued at the stations, rejected or dead-lettered.
+func (c *Consumer) prepare(ctx context.Context, msg kafka.Message) bool {
+	var event pb.Order
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal event", "error", err)
+		return c.deadLetter(ctx, msg, "unmarshal", 1, err)
+	}
+
+	slog.InfoContext(ctx, "received order", "order_id", event.ID)
+	attempts, err := c.retry.Do(ctx, "accept", func() error {
+		return c.service.AcceptOrder(ctx, &event)
+	})
+	if err != nil {
+		// A canceled order arriving late, or a completed one delivered
+		// again, is expected rather than a failure.
+		var conflict *common.ConflictError
+		if errors.As(err, &conflict) {
+			slog.WarnContext(ctx, "rejected order", "order_id", event.ID, "reason", err)
+			return true
+		}
+		slog.ErrorContext(ctx, "failed to accept order", "order_id", event.ID, "attempts", attempts, "error", err)
+		return c.deadLetter(ctx, msg, "accept", attempts, err)
+	}
+
+	// The order is left accepted if processing keeps failing, so that
+	// replaying the dead letter picks it up where it stopped.
+	attempts, err = c.retry.Do(ctx, "process", func() error {
+		return c.service.ProcessOrder(ctx, &event)
+	})
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to process order", "order_id", event.ID, "attempts", attempts, "error", err)
+		return c.deadLetter(ctx, msg, "process", attempts, err)
+	}
+
+	return true
+}
+
+// deadLetter gives up on a message. Writing it to the dead-letter topic is
+// retried until it succeeds, since an uncommitted offset holds back every
+// later offset of the partition; only a shutdown leaves the message to be
+// delivered again.
+func (c *Consumer) deadLetter(ctx context.Context, msg kafka.Message, step string, attempts int, cause error) bool {
+	for retry := 1; ctx.Err() == nil; retry++ {
+		err := c.producer.PublishDeadLetter(ctx, msg, step, attempts, cause)
+		if err == nil {
+			return true
+		}
+
+		d := c.retry.delay(retry)
+		slog.ErrorContext(ctx, "failed to dead-letter message, retrying", "offset", msg.Offset, "retry_in", d, "error", err)
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+		}
 	}
+	return false
 }
 
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
+
+// CancelConsumer records the orders customers canceled. The order service
+// only lets customers cancel orders it has not handed to the kitchen yet,
+// so this mostly makes sure such an order is rejected should it still
+// arrive. A cancellation is only committed once the kitchen
+// recorded it, so one that keeps failing is retried rather than lost.
+type CancelConsumer struct {
+	reader  *kafka.Reader
+	service KitchenService
+	retry   RetryPolicy
+}
+
+func NewCancelConsumer(brokerURL string, groupID string, service KitchenService, retry RetryPolicy) *CancelConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  []string{brokerURL},
+		Topic:    "orders.canceled",
+		GroupID:  groupID,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+
+	return &CancelConsumer{reader: reader, service: service, retry: retry}
+}
+
+// Start cancels orders until ctx is canceled.
+func (c *CancelConsumer) Start(ctx context.Context) {
+	slog.Info("starting consumer", "topic", "orders.canceled")
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to read message, retrying in 5s", "error", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		msgCtx := messageContext(ctx, msg)
+		if !c.cancel(msgCtx, msg) {
+			// Only happens on shutdown; the message is delivered again
+			// after a restart.
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			slog.ErrorContext(msgCtx, "failed to commit message", "offset", msg.Offset, "error", err)
+		}
+	}
+}
+
+// cancel cancels the order of msg. Failures that retrying cannot fix, e.g.
+// an order already being prepared, are logged and the message is done with;
+// any other failure is retried until it succeeds. It reports whether the
+// message is done with.
+func (c *CancelConsumer) cancel(ctx context.Context, msg kafka.Message) bool {
+	var event pb.CancelOrderRequest
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal event", "error", err)
+		return true
+	}
+
+	slog.InfoContext(ctx, "received order cancellation", "order_id", event.OrderID, "reason", event.Reason)
+	for retry := 1; ctx.Err() == nil; retry++ {
+		err := c.service.CancelOrder(ctx, event.OrderID)
+		if err == nil {
+			return true
+		}
+		if !retryable(err) {
+			slog.WarnContext(ctx, "rejected order cancellation", "order_id", event.OrderID, "reason", err)
+			return true
+		}
+
+		d := c.retry.delay(retry)
+		slog.ErrorContext(ctx, "failed to cancel order, retrying", "order_id", event.OrderID, "retry_in", d, "error", err)
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+		}
+	}
+	return false
+}
+
+func (c *CancelConsumer) Close() error {
+	return c.reader.Close()
+}
+
+// messageContext carries the correlation ID of msg, if it has one.
+func messageContext(ctx context.Context, msg kafka.Message) context.Context {
+	for _, h := range msg.Headers {
+		if h.Key == logging.CorrelationHeader {
+			return logging.WithCorrelationID(ctx, string(h.Value))
+		}
+	}
+	return logging.EnsureCorrelationID(ctx, "")
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Headers describing why a message was dead-lettered. They are dropped when
+// the message is replayed.
+const (
+	dlqHeaderPrefix    = "x-dlq-"
+	dlqErrorHeader     = "x-dlq-error"
+	dlqStepHeader      = "x-dlq-step"
+	dlqAttemptsHeader  = "x-dlq-attempts"
+	dlqTopicHeader     = "x-dlq-original-topic"
+	dlqPartitionHeader = "x-dlq-original-partition"
+	dlqOffsetHeader    = "x-dlq-original-offset"
+	dlqFailedAtHeader  = "x-dlq-failed-at"
+)
+
+// replayIdleTimeout is how long a replay waits for more dead letters before
+// it considers the topic drained.
+const replayIdleTimeout = 10 * time.Second
+
+// deadLetter copies msg for the dead-letter topic, with headers recording
+// which step failed, after how many attempts and why.
+func deadLetter(msg kafka.Message, step string, attempts int, cause error) kafka.Message {
+	headers := append([]kafka.Header{}, msg.Headers...)
+	headers = append(headers,
+		kafka.Header{Key: dlqErrorHeader, Value: []byte(cause.Error())},
+		kafka.Header{Key: dlqStepHeader, Value: []byte(step)},
+		kafka.Header{Key: dlqAttemptsHeader, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: dlqTopicHeader, Value: []byte(msg.Topic)},
+		kafka.Header{Key: dlqPartitionHeader, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: dlqOffsetHeader, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: dlqFailedAtHeader, Value: []byte(time.Now().UTC().Format(time.RFC3339))},
+	)
+
+	return kafka.Message{
+		Topic:   TopicOrderCreatedDLQ,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+	}
+}
+
+// RunReplayCommand moves the messages waiting in orders.created.dlq back to
+// orders.created, for the kitchen to try again once whatever made them fail
+// is fixed. It stops when no dead letter has arrived for a while.
+func RunReplayCommand(brokerURL string) error {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  []string{brokerURL},
+		Topic:    TopicOrderCreatedDLQ,
+		GroupID:  "kitchen-service-dlq-replay",
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+	defer reader.Close()
+
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokerURL),
+		Balancer:               &kafka.LeastBytes{},
+		AllowAutoTopicCreation: true,
+	}
+	defer writer.Close()
+
+	ctx := context.Background()
+	replayed := 0
+	for {
+		fetchCtx, cancel := context.WithTimeout(ctx, replayIdleTimeout)
+		msg, err := reader.FetchMessage(fetchCtx)
+		cancel()
+		if errors.Is(err, context.DeadlineExceeded) {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read dead letter: %w", err)
+		}
+
+		replay := kafka.Message{
+			Topic: TopicOrderCreated,
+			Key:   msg.Key,
+			Value: msg.Value,
+		}
+		for _, h := range msg.Headers {
+			if !strings.HasPrefix(h.Key, dlqHeaderPrefix) {
+				replay.Headers = append(replay.Headers, h)
+			}
+		}
+
+		if err := writer.WriteMessages(ctx, replay); err != nil {
+			return fmt.Errorf("failed to replay dead letter at offset %d: %w", msg.Offset, err)
+		}
+		if err := reader.CommitMessages(ctx, msg); err != nil {
+			return fmt.Errorf("failed to commit dead letter at offset %d: %w", msg.Offset, err)
+		}
+		replayed++
+		slog.Info("replayed dead letter", "key", string(msg.Key), "offset", msg.Offset, "error", header(msg, dlqErrorHeader))
+	}
+
+	slog.Info("replayed dead letters", "count", replayed)
+	return nil
+}
+
+// header returns the value of a message header, or "" if it is missing.
+func header(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
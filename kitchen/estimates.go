@@ -0,0 +1,145 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// Estimator predicts when orders will be ready from how long the stations
+// took for the same items before.
+type Estimator struct {
+	store    Store
+	stations *Stations
+	// scheduler is the one the stations use, to tell which queued tickets
+	// a new ticket waits for.
+	scheduler Scheduler
+	// fallback is the prep time assumed for a station that has not bumped
+	// any tickets yet.
+	fallback time.Duration
+	clock    Clock
+}
+
+func NewEstimator(store Store, stations *Stations, scheduler Scheduler, fallback time.Duration, clock Clock) *Estimator {
+	return &Estimator{store: store, stations: stations, scheduler: scheduler, fallback: fallback, clock: clock}
+}
+
+// Record learns from a bumped ticket how long its items take.
+func (e *Estimator) Record(ctx context.Context, t *pb.Ticket) error {
+	if t.StartedAt == nil || t.BumpedAt == nil {
+		return nil
+	}
+
+	d := t.BumpedAt.AsTime().Sub(t.StartedAt.AsTime())
+	if d < 0 {
+		return nil
+	}
+
+	itemIDs := make([]string, 0, len(t.Items))
+	for _, item := range t.Items {
+		itemIDs = append(itemIDs, item.ID)
+	}
+	return e.store.RecordPrepTime(ctx, t.Station, itemIDs, d)
+}
+
+// Estimate predicts when an order split into tickets will be ready, and
+// sets the prep time of each ticket. Each ticket waits for the tickets in
+// progress at its station and the queued ones the scheduler puts before
+// it, spread over the station's capacity, and then takes as long as its
+// slowest item; the order is ready with its last ticket.
+func (e *Estimator) Estimate(ctx context.Context, tickets []*pb.Ticket) (time.Time, error) {
+	now := e.clock.Now()
+	readyAt := now
+
+	for _, t := range tickets {
+		itemIDs := make([]string, 0, len(t.Items))
+		for _, item := range t.Items {
+			itemIDs = append(itemIDs, item.ID)
+		}
+		estimates, err := e.store.PrepEstimates(ctx, t.Station, itemIDs)
+		if err != nil {
+			return time.Time{}, err
+		}
+		t.PrepTime = durationpb.New(e.ticketPrepTime(t, estimates))
+
+		open, err := e.store.ListOpenTickets(ctx, t.Station)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		var backlog time.Duration
+		queue := []*pb.Ticket{{
+			ID:        t.ID,
+			OrderID:   t.OrderID,
+			Station:   t.Station,
+			VIP:       t.VIP,
+			Rush:      t.Rush,
+			PrepTime:  t.PrepTime,
+			CreatedAt: timestamppb.New(now),
+		}}
+		for _, o := range open {
+			if o.OrderID == t.OrderID {
+				continue
+			}
+			if o.Status != pb.TicketStatus_TICKET_IN_PROGRESS {
+				queue = append(queue, o)
+				continue
+			}
+			d := e.prepTime(o)
+			if o.StartedAt != nil {
+				d = max(d-now.Sub(o.StartedAt.AsTime()), 0)
+			}
+			backlog += d
+		}
+
+		// The new ticket waits for the queued tickets the scheduler would
+		// start before it.
+		candidate := queue[0]
+		e.scheduler.Schedule(now, queue)
+		for _, o := range queue {
+			if o == candidate {
+				break
+			}
+			backlog += e.prepTime(o)
+		}
+
+		wait := backlog / time.Duration(max(e.stations.Capacity(t.Station), 1))
+		ready := now.Add(wait + t.PrepTime.AsDuration())
+		if ready.After(readyAt) {
+			readyAt = ready
+		}
+	}
+
+	return readyAt, nil
+}
+
+// prepTime is the prep time estimated for a ticket when it was queued.
+func (e *Estimator) prepTime(t *pb.Ticket) time.Duration {
+	if t.PrepTime == nil {
+		return e.fallback
+	}
+	return t.PrepTime.AsDuration()
+}
+
+// ticketPrepTime is the estimate of a ticket's slowest item. Items without
+// an estimate of their own are assumed to take as long as the station
+// usually does.
+func (e *Estimator) ticketPrepTime(t *pb.Ticket, estimates map[string]time.Duration) time.Duration {
+	station, ok := estimates[""]
+	if !ok {
+		station = e.fallback
+	}
+
+	var d time.Duration
+	for _, item := range t.Items {
+		itemTime, ok := estimates[item.ID]
+		if !ok {
+			itemTime = station
+		}
+		d = max(d, itemTime)
+	}
+	return d
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/grpc"
+)
+
+type Handler struct {
+	pb.UnimplementedKitchenServiceServer
+	service KitchenService
+}
+
+func NewHandler(s *grpc.Server, service KitchenService) *Handler {
+	h := &Handler{
+		service: service,
+	}
+	pb.RegisterKitchenServiceServer(s, h)
+	return h
+}
+
+func (h *Handler) ListTickets(ctx context.Context, req *pb.ListTicketsRequest) (*pb.ListTicketsResponse, error) {
+	tickets, err := h.service.ListTickets(ctx, req.Station)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListTicketsResponse{Tickets: tickets}, nil
+}
+
+func (h *Handler) StartTicket(ctx context.Context, req *pb.StartTicketRequest) (*pb.Ticket, error) {
+	return h.service.StartTicket(ctx, req.TicketID)
+}
+
+func (h *Handler) BumpTicket(ctx context.Context, req *pb.BumpTicketRequest) (*pb.Ticket, error) {
+	return h.service.BumpTicket(ctx, req.TicketID)
+}
+
+func (h *Handler) RecallTicket(ctx context.Context, req *pb.RecallTicketRequest) (*pb.Ticket, error) {
+	return h.service.RecallTicket(ctx, req.TicketID)
+}
+
+func (h *Handler) VoidTicket(ctx context.Context, req *pb.VoidTicketRequest) (*pb.Ticket, error) {
+	return h.service.VoidTicket(ctx, req.TicketID, req.Reason)
+}
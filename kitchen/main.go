@@ -2,35 +2,155 @@ package main
 
 import (
 	"context"
-	"log"
+	"flag"
+	"log/slog"
+	"net"
 	"path/filepath"
+	"strconv"
+	"time"
 
 	common "github.com/kiriyms/oms_go-common"
+	"github.com/kiriyms/oms_go-common/logging"
+	"github.com/kiriyms/oms_go-common/outbox"
+	"google.golang.org/grpc"
 )
 
 var (
+	grpcAddr  = common.GetEnv("GRPC_ADDR", "localhost:50054")
 	dbPath    = common.GetEnv("DB_PATH", "./db/db.db")
 	brokerURL = common.GetEnv("KAFKA_BROKER_URL", "localhost:9092")
+	stations  = common.GetEnv("KITCHEN_STATIONS", "grill:2,fryer:2,salad:1,bar:1")
+	// defaultStation takes the items whose station is unset or unknown.
+	defaultStation = common.GetEnv("KITCHEN_DEFAULT_STATION", "grill")
+	// defaultPrepTime is assumed for stations that have not bumped a ticket
+	// yet, until there are samples to estimate from.
+	defaultPrepTime = common.GetEnv("KITCHEN_DEFAULT_PREP_TIME", "5m")
+	workers         = common.GetEnv("KITCHEN_WORKERS", "4")
+	queueSize       = common.GetEnv("KITCHEN_QUEUE_SIZE", "16")
+	// scheduler picks which queued ticket a station starts next: fifo,
+	// priority, shortest-prep or fire-together.
+	scheduler = common.GetEnv("KITCHEN_SCHEDULER", "fifo")
+	// retryAttempts is how often a step of taking in an order is tried
+	// before the order is moved to orders.created.dlq. The wait between
+	// tries starts at retryBackoff and doubles up to retryMaxBackoff.
+	retryAttempts   = common.GetEnv("KITCHEN_RETRY_ATTEMPTS", "5")
+	retryBackoff    = common.GetEnv("KITCHEN_RETRY_BACKOFF", "500ms")
+	retryMaxBackoff = common.GetEnv("KITCHEN_RETRY_MAX_BACKOFF", "30s")
+)
+
+const outboxInterval = time.Second
+
+var (
+	printPendingMigrations = flag.Bool("pending-migrations", false, "print pending database migrations and exit")
+	migrateDown            = flag.Int("migrate-down", 0, "roll back the given number of database migrations and exit")
+	replayDLQ              = flag.Bool("replay-dlq", false, "move the messages in orders.created.dlq back to orders.created and exit")
 )
 
 func main() {
+	logging.Init("kitchen")
+
+	flag.Parse()
+	if *printPendingMigrations || *migrateDown > 0 {
+		if err := RunMigrationCommand(dbPath, *printPendingMigrations, *migrateDown); err != nil {
+			logging.Fatal("migration command failed", "error", err)
+		}
+		return
+	}
+	if *replayDLQ {
+		if err := RunReplayCommand(brokerURL); err != nil {
+			logging.Fatal("replay command failed", "error", err)
+		}
+		return
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), common.UnaryErrorInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), common.StreamErrorInterceptor()),
+	)
+	l, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logging.Fatal("failed to listen", "error", err)
+	}
+	defer l.Close()
+
 	abs, _ := filepath.Abs(dbPath)
-	log.Println("Using DB at:", abs)
-	
+	slog.Info("using database", "path", abs)
+
 	store, err := NewStore(dbPath)
 	if err != nil {
-		log.Fatalf("Failed to create store: %v", err)
+		logging.Fatal("failed to create store", "error", err)
 	}
 	defer store.Close()
 
 	producer := NewProducer(brokerURL)
 	defer producer.Close()
 
-	service := NewService(store, producer)
+	stationConfigs, err := parseStations(stations)
+	if err != nil {
+		logging.Fatal("invalid KITCHEN_STATIONS", "value", stations, "error", err)
+	}
+
+	ticketScheduler, err := NewScheduler(scheduler)
+	if err != nil {
+		logging.Fatal("invalid KITCHEN_SCHEDULER", "value", scheduler, "error", err)
+	}
+
+	clock := systemClock{}
+	kitchen, err := NewStations(stationConfigs, defaultStation, ticketScheduler, clock)
+	if err != nil {
+		logging.Fatal("failed to set up stations", "error", err)
+	}
+
+	prepTime, err := time.ParseDuration(defaultPrepTime)
+	if err != nil || prepTime <= 0 {
+		logging.Fatal("invalid KITCHEN_DEFAULT_PREP_TIME, want a positive duration", "value", defaultPrepTime)
+	}
+
+	service := NewService(store, producer, kitchen, NewEstimator(store, kitchen, ticketScheduler, prepTime, clock))
+	NewHandler(grpcServer, service)
+
+	poolSize, err := strconv.Atoi(workers)
+	if err != nil || poolSize < 1 {
+		logging.Fatal("invalid KITCHEN_WORKERS, want a positive integer", "value", workers)
+	}
+
+	queueLen, err := strconv.Atoi(queueSize)
+	if err != nil || queueLen < 0 {
+		logging.Fatal("invalid KITCHEN_QUEUE_SIZE, want a non-negative integer", "value", queueSize)
+	}
+
+	attempts, err := strconv.Atoi(retryAttempts)
+	if err != nil || attempts < 1 {
+		logging.Fatal("invalid KITCHEN_RETRY_ATTEMPTS, want a positive integer", "value", retryAttempts)
+	}
 
-	consumer := NewConsumer(brokerURL, "kitchen-service", service)
+	backoff, err := time.ParseDuration(retryBackoff)
+	if err != nil || backoff <= 0 {
+		logging.Fatal("invalid KITCHEN_RETRY_BACKOFF, want a positive duration", "value", retryBackoff)
+	}
+
+	maxBackoff, err := time.ParseDuration(retryMaxBackoff)
+	if err != nil || maxBackoff < backoff {
+		logging.Fatal("invalid KITCHEN_RETRY_MAX_BACKOFF, want a duration of at least KITCHEN_RETRY_BACKOFF", "value", retryMaxBackoff)
+	}
+
+	retry := RetryPolicy{Attempts: attempts, Backoff: backoff, MaxBackoff: maxBackoff}
+	consumer := NewConsumer(brokerURL, "kitchen-service", producer, service, retry, poolSize, queueLen)
 	defer consumer.Close()
 
+	cancelConsumer := NewCancelConsumer(brokerURL, "kitchen-service-cancellations", service, retry)
+	defer cancelConsumer.Close()
+
 	ctx := context.Background()
-	consumer.Start(ctx)
+	relay := outbox.NewRelay(store, producer, outboxInterval)
+	go relay.Start(ctx)
+
+	go cancelConsumer.Start(ctx)
+	go consumer.Start(ctx)
+
+	slog.Info("grpc server listening", "addr", grpcAddr)
+
+	if err := grpcServer.Serve(l); err != nil {
+		logging.Fatal("grpc server stopped", "error", err)
+	}
 }
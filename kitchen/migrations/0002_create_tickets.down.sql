@@ -0,0 +1,2 @@
+DROP TABLE ticket_items;
+DROP TABLE tickets;
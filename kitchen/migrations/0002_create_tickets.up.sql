@@ -0,0 +1,22 @@
+CREATE TABLE tickets (
+    id         TEXT PRIMARY KEY,
+    order_id   TEXT NOT NULL,
+    station    TEXT NOT NULL,
+    status     TEXT NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    started_at DATETIME,
+    bumped_at  DATETIME
+);
+
+CREATE INDEX idx_tickets_station_status ON tickets (station, status, created_at);
+CREATE INDEX idx_tickets_order_id ON tickets (order_id);
+
+CREATE TABLE ticket_items (
+    id        INTEGER PRIMARY KEY AUTOINCREMENT,
+    ticket_id TEXT NOT NULL REFERENCES tickets (id) ON DELETE CASCADE,
+    item_id   TEXT NOT NULL,
+    name      TEXT NOT NULL DEFAULT '',
+    quantity  INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX idx_ticket_items_ticket_id ON ticket_items (ticket_id);
@@ -0,0 +1 @@
+ALTER TABLE tickets DROP COLUMN void_reason;
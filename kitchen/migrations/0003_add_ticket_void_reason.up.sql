@@ -0,0 +1 @@
+ALTER TABLE tickets ADD COLUMN void_reason TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+DROP TABLE prep_estimates;
@@ -0,0 +1,8 @@
+CREATE TABLE prep_estimates (
+    station     TEXT NOT NULL,
+    item_id     TEXT NOT NULL,
+    estimate_ms INTEGER NOT NULL,
+    samples     INTEGER NOT NULL,
+    updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (station, item_id)
+);
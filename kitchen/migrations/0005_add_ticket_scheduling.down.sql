@@ -0,0 +1,4 @@
+ALTER TABLE tickets DROP COLUMN fire_at;
+ALTER TABLE tickets DROP COLUMN prep_ms;
+ALTER TABLE tickets DROP COLUMN rush;
+ALTER TABLE tickets DROP COLUMN vip;
@@ -0,0 +1,4 @@
+ALTER TABLE tickets ADD COLUMN vip INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tickets ADD COLUMN rush INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tickets ADD COLUMN prep_ms INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE tickets ADD COLUMN fire_at DATETIME;
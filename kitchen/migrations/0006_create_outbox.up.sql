@@ -0,0 +1,13 @@
+CREATE TABLE outbox (
+    id             INTEGER PRIMARY KEY AUTOINCREMENT,
+    topic          TEXT NOT NULL,
+    msg_key        TEXT NOT NULL,
+    payload        BLOB NOT NULL,
+    attempts       INTEGER NOT NULL DEFAULT 0,
+    last_error     TEXT,
+    correlation_id TEXT NOT NULL DEFAULT '',
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    published_at   DATETIME
+);
+
+CREATE INDEX idx_outbox_unpublished ON outbox (published_at, id);
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"sync"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// offsetTracker decides which offsets may be committed while messages are
+// handled concurrently. Committing an offset acknowledges every earlier one
+// in the partition, so a message is only committed once it and all messages
+// fetched before it from the same partition are done.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[int]*partitionOffsets
+}
+
+type partitionOffsets struct {
+	// pending holds the fetched messages that were not committed yet, in
+	// offset order.
+	pending []kafka.Message
+	done    map[int64]bool
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[int]*partitionOffsets)}
+}
+
+// track records a fetched message. Messages must be tracked in the order
+// they were fetched.
+func (t *offsetTracker) track(msg kafka.Message) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[msg.Partition]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[msg.Partition] = p
+	}
+	p.pending = append(p.pending, msg)
+}
+
+// complete marks msg as done and, if that completes a run of messages at the
+// start of its partition, commits the last of them. Commits are serialized
+// so that a partition's committed offset never moves backwards.
+func (t *offsetTracker) complete(msg kafka.Message, commit func(kafka.Message) error) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p, ok := t.partitions[msg.Partition]
+	if !ok {
+		return nil
+	}
+	p.done[msg.Offset] = true
+
+	n := 0
+	for n < len(p.pending) && p.done[p.pending[n].Offset] {
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+
+	if err := commit(p.pending[n-1]); err != nil {
+		return err
+	}
+
+	for _, m := range p.pending[:n] {
+		delete(p.done, m.Offset)
+	}
+	p.pending = p.pending[n:]
+	return nil
+}
@@ -0,0 +1,90 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// prepEstimateWeight is how far a new sample moves a prep time estimate.
+// Until an estimate has that many samples, they are simply averaged, so the
+// first few do not carry more weight than later ones.
+const prepEstimateWeight = 0.2
+
+type PrepTimeStore interface {
+	// RecordPrepTime folds the time a ticket took into the estimates of its
+	// items and of its station.
+	RecordPrepTime(ctx context.Context, station string, itemIDs []string, d time.Duration) error
+	// PrepEstimates returns the estimates of the given items at a station
+	// by item ID, and the station's own estimate under "". Items without
+	// samples are missing.
+	PrepEstimates(ctx context.Context, station string, itemIDs []string) (map[string]time.Duration, error)
+}
+
+func (s *store) RecordPrepTime(ctx context.Context, station string, itemIDs []string, d time.Duration) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	seen := make(map[string]bool)
+	for _, itemID := range append([]string{""}, itemIDs...) {
+		if seen[itemID] {
+			continue
+		}
+		seen[itemID] = true
+
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO prep_estimates (station, item_id, estimate_ms, samples, updated_at)
+			VALUES (?, ?, ?, 1, CURRENT_TIMESTAMP)
+			ON CONFLICT(station, item_id) DO UPDATE SET
+				estimate_ms = CAST(ROUND(estimate_ms + MAX(?, 1.0 / (samples + 1)) * (excluded.estimate_ms - estimate_ms)) AS INTEGER),
+				samples = samples + 1,
+				updated_at = CURRENT_TIMESTAMP
+		`, station, itemID, d.Milliseconds(), prepEstimateWeight)
+		if err != nil {
+			return fmt.Errorf("failed to record prep time of %q: %w", itemID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *store) PrepEstimates(ctx context.Context, station string, itemIDs []string) (map[string]time.Duration, error) {
+	placeholders := make([]string, 0, len(itemIDs)+1)
+	args := []any{station}
+	for _, itemID := range append([]string{""}, itemIDs...) {
+		placeholders = append(placeholders, "?")
+		args = append(args, itemID)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT item_id, estimate_ms
+		FROM prep_estimates
+		WHERE station = ?
+		  AND item_id IN (`+strings.Join(placeholders, ", ")+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prep estimates: %w", err)
+	}
+	defer rows.Close()
+
+	estimates := make(map[string]time.Duration)
+	for rows.Next() {
+		var (
+			itemID string
+			ms     int64
+		)
+		if err := rows.Scan(&itemID, &ms); err != nil {
+			return nil, fmt.Errorf("failed to scan prep estimate: %w", err)
+		}
+		estimates[itemID] = time.Duration(ms) * time.Millisecond
+	}
+
+	return estimates, rows.Err()
+}
@@ -3,45 +3,81 @@ package main
 import (
 	"context"
 	"encoding/json"
-	"log"
+	"log/slog"
 
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
 	"github.com/segmentio/kafka-go"
 )
 
+const (
+	TopicOrderCreated     = "orders.created"
+	TopicOrderCreatedDLQ  = "orders.created.dlq"
+	TopicOrderFinished    = "orders.finished"
+	TopicKitchenAccepted  = "kitchen.accepted"
+	TopicKitchenPreparing = "kitchen.preparing"
+	TopicKitchenReady     = "kitchen.ready"
+	TopicKitchenFailed    = "kitchen.failed"
+)
+
 type Producer struct {
 	writer *kafka.Writer
 }
 
 func NewProducer(brokerURL string) *Producer {
-	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  []string{brokerURL},
-		Topic:    "orders.finished",
-		Balancer: &kafka.LeastBytes{},
-	})
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokerURL),
+		Balancer:               &kafka.LeastBytes{},
+		AllowAutoTopicCreation: true,
+	}
 
 	return &Producer{writer: writer}
 }
 
-func (p *Producer) PublishOrderFinished(ctx context.Context, order *pb.Order) error {
-	log.Printf("Publishing order.finished event: %v", order)
-	valueBytes, err := json.Marshal(order)
+// PublishKitchenEvent publishes event on one of the kitchen.* topics.
+func (p *Producer) PublishKitchenEvent(ctx context.Context, topic string, event *pb.KitchenEvent) error {
+	return p.publish(ctx, topic, event.OrderID, event)
+}
+
+// PublishDeadLetter moves an orders.created message the kitchen gave up on
+// to orders.created.dlq, with the reason in its headers.
+func (p *Producer) PublishDeadLetter(ctx context.Context, msg kafka.Message, step string, attempts int, cause error) error {
+	err := p.writer.WriteMessages(ctx, deadLetter(msg, step, attempts, cause))
 	if err != nil {
+		slog.ErrorContext(ctx, "failed to write message", "topic", TopicOrderCreatedDLQ, "error", err)
 		return err
 	}
 
+	slog.WarnContext(ctx, "dead-lettered message", "topic", TopicOrderCreatedDLQ, "key", string(msg.Key), "step", step, "attempts", attempts, "error", cause)
+	return nil
+}
+
+func (p *Producer) publish(ctx context.Context, topic string, key string, value any) error {
+	valueBytes, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return p.Publish(ctx, topic, key, valueBytes)
+}
+
+// Publish writes an already encoded event, e.g. one relayed from the outbox.
+func (p *Producer) Publish(ctx context.Context, topic string, key string, value []byte) error {
 	msg := kafka.Message{
-		Key:   []byte(order.ID),
-		Value: valueBytes,
+		Topic: topic,
+		Key:   []byte(key),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: logging.CorrelationHeader, Value: []byte(logging.CorrelationID(ctx))},
+		},
 	}
 
-	err = p.writer.WriteMessages(ctx, msg)
+	err := p.writer.WriteMessages(ctx, msg)
 	if err != nil {
-		log.Printf("failed to write message: %v", err)
+		slog.ErrorContext(ctx, "failed to write message", "topic", topic, "error", err)
 		return err
 	}
 
-	log.Printf("published order.finished event: %s", order.ID)
+	slog.InfoContext(ctx, "published event", "topic", topic, "key", key)
 	return nil
 }
 
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+)
+
+// RetryPolicy is how often and how patiently the consumer retries a step of
+// taking in an order before giving up on the message.
+type RetryPolicy struct {
+	// Attempts is the number of tries, including the first.
+	Attempts int
+	// Backoff is the wait before the first retry. It doubles with every
+	// retry, up to MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// delay is the wait before the given retry, counting from 1.
+func (p RetryPolicy) delay(retry int) time.Duration {
+	d := p.Backoff
+	for range retry - 1 {
+		if d >= p.MaxBackoff/2 {
+			return p.MaxBackoff
+		}
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+// Do runs fn until it succeeds, fails with an error that retrying cannot
+// fix, or runs out of attempts. It returns the number of attempts made and
+// the last error.
+func (p RetryPolicy) Do(ctx context.Context, step string, fn func() error) (int, error) {
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		err = fn()
+		if err == nil || !retryable(err) || attempt >= p.Attempts {
+			return attempt, err
+		}
+
+		d := p.delay(attempt)
+		slog.WarnContext(ctx, "step failed, retrying", "step", step, "attempt", attempt, "retry_in", d, "error", err)
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return attempt, err
+		}
+	}
+}
+
+// retryable reports whether err may go away on a retry. Invalid orders stay
+// invalid, and orders in the wrong state stay there.
+func retryable(err error) bool {
+	var (
+		invalid  *common.InvalidArgumentError
+		conflict *common.ConflictError
+	)
+	return !errors.As(err, &invalid) && !errors.As(err, &conflict)
+}
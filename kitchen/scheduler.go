@@ -0,0 +1,100 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// Scheduler decides in which order a station starts its queued tickets.
+type Scheduler interface {
+	// Schedule sorts a station's queued tickets so that the one to start
+	// next comes first.
+	Schedule(now time.Time, tickets []*pb.Ticket)
+}
+
+// NewScheduler returns the scheduler with the given name: fifo, priority,
+// shortest-prep or fire-together.
+func NewScheduler(name string) (Scheduler, error) {
+	switch name {
+	case "fifo":
+		return fifoScheduler{}, nil
+	case "priority":
+		return priorityScheduler{}, nil
+	case "shortest-prep":
+		return shortestPrepScheduler{}, nil
+	case "fire-together":
+		return fireTogetherScheduler{}, nil
+	default:
+		return nil, fmt.Errorf("unknown kitchen scheduler %q", name)
+	}
+}
+
+// fifoScheduler starts tickets in the order they were queued.
+type fifoScheduler struct{}
+
+func (fifoScheduler) Schedule(_ time.Time, tickets []*pb.Ticket) {
+	slices.SortStableFunc(tickets, compareQueued)
+}
+
+// priorityScheduler starts rush tickets first, then VIP ones, then the
+// rest, each in the order they were queued.
+type priorityScheduler struct{}
+
+func (priorityScheduler) Schedule(_ time.Time, tickets []*pb.Ticket) {
+	slices.SortStableFunc(tickets, func(a, b *pb.Ticket) int {
+		return cmp.Or(cmp.Compare(priorityRank(a), priorityRank(b)), compareQueued(a, b))
+	})
+}
+
+func priorityRank(t *pb.Ticket) int {
+	switch {
+	case t.Rush:
+		return 0
+	case t.VIP:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// shortestPrepScheduler starts the quickest tickets first, which gets the
+// most orders out. The time a ticket has waited counts against its prep
+// time, so that a long ticket is not held back forever by a stream of
+// quick ones.
+type shortestPrepScheduler struct{}
+
+func (shortestPrepScheduler) Schedule(now time.Time, tickets []*pb.Ticket) {
+	key := func(t *pb.Ticket) time.Duration {
+		return t.PrepTime.AsDuration() - now.Sub(t.CreatedAt.AsTime())
+	}
+	slices.SortStableFunc(tickets, func(a, b *pb.Ticket) int {
+		return cmp.Or(cmp.Compare(key(a), key(b)), compareQueued(a, b))
+	})
+}
+
+// fireTogetherScheduler starts tickets by their FireAt, so that the
+// tickets of an order, e.g. the courses of a multi-course order, come out
+// of their stations together rather than the quick ones going cold. Tickets
+// without a FireAt are due when they were queued.
+type fireTogetherScheduler struct{}
+
+func (fireTogetherScheduler) Schedule(_ time.Time, tickets []*pb.Ticket) {
+	fireAt := func(t *pb.Ticket) time.Time {
+		if t.FireAt != nil {
+			return t.FireAt.AsTime()
+		}
+		return t.CreatedAt.AsTime()
+	}
+	slices.SortStableFunc(tickets, func(a, b *pb.Ticket) int {
+		return cmp.Or(fireAt(a).Compare(fireAt(b)), compareQueued(a, b))
+	})
+}
+
+// compareQueued orders tickets by when they were queued.
+func compareQueued(a, b *pb.Ticket) int {
+	return cmp.Or(a.CreatedAt.AsTime().Compare(b.CreatedAt.AsTime()), cmp.Compare(a.ID, b.ID))
+}
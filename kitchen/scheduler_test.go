@@ -0,0 +1,205 @@
+package main
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// fakeClock is a Clock stopped at a fixed time.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c fakeClock) Now() time.Time {
+	return c.now
+}
+
+var testNow = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// queued returns a queued ticket that has waited for the given time and
+// takes prep to prepare.
+func queued(id string, waited time.Duration, prep time.Duration) *pb.Ticket {
+	return &pb.Ticket{
+		ID:        id,
+		Status:    pb.TicketStatus_TICKET_QUEUED,
+		PrepTime:  durationpb.New(prep),
+		CreatedAt: timestamppb.New(testNow.Add(-waited)),
+	}
+}
+
+func vip(t *pb.Ticket) *pb.Ticket {
+	t.VIP = true
+	return t
+}
+
+func rush(t *pb.Ticket) *pb.Ticket {
+	t.Rush = true
+	return t
+}
+
+func fireAt(t *pb.Ticket, in time.Duration) *pb.Ticket {
+	t.FireAt = timestamppb.New(testNow.Add(in))
+	return t
+}
+
+func TestSchedulers(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheduler string
+		tickets   []*pb.Ticket
+		want      []string
+	}{
+		{
+			name:      "fifo starts the longest waiting first",
+			scheduler: "fifo",
+			tickets: []*pb.Ticket{
+				queued("b", 2*time.Minute, time.Minute),
+				queued("c", time.Minute, time.Minute),
+				queued("a", 3*time.Minute, time.Minute),
+			},
+			want: []string{"a", "b", "c"},
+		},
+		{
+			name:      "fifo breaks ties by ID",
+			scheduler: "fifo",
+			tickets: []*pb.Ticket{
+				queued("b", time.Minute, time.Minute),
+				queued("a", time.Minute, time.Minute),
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:      "fifo ignores priority",
+			scheduler: "fifo",
+			tickets: []*pb.Ticket{
+				rush(queued("b", time.Minute, time.Minute)),
+				queued("a", 2*time.Minute, time.Minute),
+			},
+			want: []string{"a", "b"},
+		},
+		{
+			name:      "priority starts rush, then VIP, then the rest",
+			scheduler: "priority",
+			tickets: []*pb.Ticket{
+				queued("a", 4*time.Minute, time.Minute),
+				vip(queued("b", 3*time.Minute, time.Minute)),
+				rush(queued("c", 2*time.Minute, time.Minute)),
+				vip(queued("d", time.Minute, time.Minute)),
+			},
+			want: []string{"c", "b", "d", "a"},
+		},
+		{
+			name:      "priority prefers rush over VIP",
+			scheduler: "priority",
+			tickets: []*pb.Ticket{
+				vip(queued("a", 2*time.Minute, time.Minute)),
+				vip(rush(queued("b", time.Minute, time.Minute))),
+			},
+			want: []string{"b", "a"},
+		},
+		{
+			name:      "shortest-prep starts the quickest first",
+			scheduler: "shortest-prep",
+			tickets: []*pb.Ticket{
+				queued("a", 0, 10*time.Minute),
+				queued("b", 0, 2*time.Minute),
+				queued("c", 0, 5*time.Minute),
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name:      "shortest-prep counts waiting against prep time",
+			scheduler: "shortest-prep",
+			tickets: []*pb.Ticket{
+				queued("quick", 0, 2*time.Minute),
+				queued("slow", 9*time.Minute, 10*time.Minute),
+			},
+			want: []string{"slow", "quick"},
+		},
+		{
+			name:      "fire-together starts by fire time",
+			scheduler: "fire-together",
+			tickets: []*pb.Ticket{
+				fireAt(queued("a", 3*time.Minute, time.Minute), 5*time.Minute),
+				fireAt(queued("b", time.Minute, time.Minute), time.Minute),
+				fireAt(queued("c", 2*time.Minute, time.Minute), 3*time.Minute),
+			},
+			want: []string{"b", "c", "a"},
+		},
+		{
+			name:      "fire-together treats tickets without a fire time as due when queued",
+			scheduler: "fire-together",
+			tickets: []*pb.Ticket{
+				fireAt(queued("later", 5*time.Minute, time.Minute), time.Minute),
+				queued("now", time.Minute, time.Minute),
+			},
+			want: []string{"now", "later"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheduler, err := NewScheduler(tt.scheduler)
+			if err != nil {
+				t.Fatal(err)
+			}
+			stations, err := NewStations([]StationConfig{{Name: "grill", Capacity: 1}}, "grill", scheduler, fakeClock{now: testNow})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var got []string
+			for _, ticket := range stations.Arrange(tt.tickets) {
+				got = append(got, ticket.ID)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArrangePutsTicketsInProgressFirst(t *testing.T) {
+	scheduler, err := NewScheduler("fifo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	stations, err := NewStations([]StationConfig{{Name: "grill", Capacity: 2}}, "grill", scheduler, fakeClock{now: testNow})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	started := func(id string, ago time.Duration) *pb.Ticket {
+		return &pb.Ticket{
+			ID:        id,
+			Status:    pb.TicketStatus_TICKET_IN_PROGRESS,
+			CreatedAt: timestamppb.New(testNow.Add(-time.Hour)),
+			StartedAt: timestamppb.New(testNow.Add(-ago)),
+		}
+	}
+
+	tickets := []*pb.Ticket{
+		queued("q", 10*time.Minute, time.Minute),
+		started("late", time.Minute),
+		started("early", 5*time.Minute),
+	}
+
+	var got []string
+	for _, ticket := range stations.Arrange(tickets) {
+		got = append(got, ticket.ID)
+	}
+	if want := []string{"early", "late", "q"}; !slices.Equal(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestNewSchedulerRejectsUnknownName(t *testing.T) {
+	if _, err := NewScheduler("lifo"); err == nil {
+		t.Error("got no error for an unknown scheduler")
+	}
+}
@@ -2,41 +2,87 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
-	"log"
-	"time"
+	"log/slog"
+	"strings"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/outbox"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type KitchenService interface {
 	AcceptOrder(context.Context, *pb.Order) error
 	ProcessOrder(context.Context, *pb.Order) error
 	FinishOrder(context.Context, string) error
+	CancelOrder(context.Context, string) error
+
+	ListTickets(ctx context.Context, station string) ([]*pb.Ticket, error)
+	StartTicket(ctx context.Context, ticketID string) (*pb.Ticket, error)
+	BumpTicket(ctx context.Context, ticketID string) (*pb.Ticket, error)
+	RecallTicket(ctx context.Context, ticketID string) (*pb.Ticket, error)
+	VoidTicket(ctx context.Context, ticketID string, reason string) (*pb.Ticket, error)
 }
 
 type service struct {
-	store    Store
-	producer *Producer
+	store     Store
+	producer  *Producer
+	stations  *Stations
+	estimator *Estimator
 }
 
-func NewService(store Store, producer *Producer) *service {
-	return &service{store: store, producer: producer}
+func NewService(store Store, producer *Producer, stations *Stations, estimator *Estimator) *service {
+	return &service{store: store, producer: producer, stations: stations, estimator: estimator}
 }
 
 func (s *service) AcceptOrder(ctx context.Context, o *pb.Order) error {
+	if o.ID == "" {
+		return common.InvalidArgument("order ID", "is required")
+	}
+
+	o.Status = pb.OrderStatus_ACCEPTED.String()
 	err := s.store.AcceptOrder(ctx, o)
 	if err != nil {
-		log.Printf("Failed to accept order: %v", err)
 		return err
 	}
-	log.Printf("Accepted order: %v", o)
+	slog.InfoContext(ctx, "accepted order", "order_id", o.ID, "items", len(o.Items))
 	return nil
 }
 
+// ProcessOrder queues an order's items at their stations as tickets and
+// announces the order as accepted, with an estimate of when it will be
+// ready. The order is finished once the cooks have bumped every ticket.
 func (s *service) ProcessOrder(ctx context.Context, o *pb.Order) error {
-	time.Sleep(10 * time.Second)
-	log.Printf("Processed order: %v", o)
+	tickets := s.stations.Route(ctx, o)
+
+	// The estimate is taken before the tickets join the queues, so that
+	// they only wait for the work already there.
+	readyAt, err := s.estimator.Estimate(ctx, tickets)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to estimate order", "order_id", o.ID, "error", err)
+	} else {
+		for _, t := range tickets {
+			t.FireAt = timestamppb.New(readyAt.Add(-t.PrepTime.AsDuration()))
+		}
+	}
+
+	if err := s.store.CreateTickets(ctx, tickets); err != nil {
+		return err
+	}
+
+	for _, t := range tickets {
+		slog.InfoContext(ctx, "queued ticket", "order_id", o.ID, "ticket_id", t.ID, "station", t.Station, "items", len(t.Items))
+	}
+
+	event := &pb.KitchenEvent{OrderID: o.ID}
+	if !readyAt.IsZero() {
+		event.EstimatedReadyAt = timestamppb.New(readyAt)
+		slog.InfoContext(ctx, "estimated order", "order_id", o.ID, "ready_at", readyAt)
+	}
+	s.publishEvent(ctx, TopicKitchenAccepted, event)
 	return nil
 }
 
@@ -46,13 +92,179 @@ func (s *service) FinishOrder(ctx context.Context, orderId string) error {
 		return err
 	}
 	if o == nil {
-		return fmt.Errorf("order not found: %s", orderId)
+		return common.NotFound("order", orderId)
 	}
-	err = s.store.FinishOrder(ctx, orderId)
+
+	// orders.finished is what completes the order, so it goes through the
+	// outbox rather than being lost if Kafka is down when the order leaves
+	// the kitchen.
+	payload, err := json.Marshal(o)
 	if err != nil {
 		return err
 	}
-	s.producer.PublishOrderFinished(ctx, o)
-	log.Printf("Finished order: %s", orderId)
+	err = s.store.FinishOrder(ctx, orderId, outbox.Message{
+		Topic:   TopicOrderFinished,
+		Key:     o.ID,
+		Payload: payload,
+	})
+	if err != nil {
+		return err
+	}
+	s.publish(ctx, TopicKitchenReady, orderId, nil, "")
+	slog.InfoContext(ctx, "finished order", "order_id", orderId)
+	return nil
+}
+
+// CancelOrder stops an order the kitchen has not started preparing yet.
+// Orders already in preparation are left to finish.
+func (s *service) CancelOrder(ctx context.Context, orderID string) error {
+	if err := s.store.CancelOrder(ctx, orderID); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "canceled order", "order_id", orderID)
 	return nil
 }
+
+func (s *service) ListTickets(ctx context.Context, station string) ([]*pb.Ticket, error) {
+	station = strings.ToLower(strings.TrimSpace(station))
+	if s.stations.Capacity(station) == 0 {
+		return nil, common.InvalidArgument("station", fmt.Sprintf("%q is not a kitchen station", station))
+	}
+
+	tickets, err := s.store.ListOpenTickets(ctx, station)
+	if err != nil {
+		return nil, err
+	}
+	return s.stations.Arrange(tickets), nil
+}
+
+// StartTicket takes a queued ticket in progress. The first ticket started
+// moves its order to preparing, after which it can no longer be canceled.
+func (s *service) StartTicket(ctx context.Context, ticketID string) (*pb.Ticket, error) {
+	if ticketID == "" {
+		return nil, common.InvalidArgument("ticket ID", "is required")
+	}
+
+	t, err := s.store.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err = s.store.StartTicket(ctx, ticketID, s.stations.Capacity(t.Station))
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "started ticket", "order_id", t.OrderID, "ticket_id", t.ID, "station", t.Station)
+	s.publish(ctx, TopicKitchenPreparing, t.OrderID, t, "")
+	return t, nil
+}
+
+// BumpTicket marks a ticket as done. Bumping an order's last ticket
+// finishes the order.
+func (s *service) BumpTicket(ctx context.Context, ticketID string) (*pb.Ticket, error) {
+	if ticketID == "" {
+		return nil, common.InvalidArgument("ticket ID", "is required")
+	}
+
+	t, ready, err := s.store.BumpTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "bumped ticket", "order_id", t.OrderID, "ticket_id", t.ID, "station", t.Station)
+
+	if err := s.estimator.Record(ctx, t); err != nil {
+		slog.WarnContext(ctx, "failed to record prep time", "ticket_id", t.ID, "error", err)
+	}
+
+	if ready {
+		if err := s.FinishOrder(ctx, t.OrderID); err != nil {
+			// Another bump may have finished the order concurrently.
+			var notFound *common.NotFoundError
+			if !errors.As(err, &notFound) {
+				return nil, err
+			}
+		}
+	}
+	return t, nil
+}
+
+func (s *service) RecallTicket(ctx context.Context, ticketID string) (*pb.Ticket, error) {
+	if ticketID == "" {
+		return nil, common.InvalidArgument("ticket ID", "is required")
+	}
+
+	t, err := s.store.RecallTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+	slog.InfoContext(ctx, "recalled ticket", "order_id", t.OrderID, "ticket_id", t.ID, "station", t.Station)
+	return t, nil
+}
+
+// VoidTicket gives up on a ticket, failing its order.
+func (s *service) VoidTicket(ctx context.Context, ticketID string, reason string) (*pb.Ticket, error) {
+	if ticketID == "" {
+		return nil, common.InvalidArgument("ticket ID", "is required")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		reason = "voided by the kitchen"
+	}
+
+	t, err := s.store.GetTicket(ctx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// kitchen.failed is what refunds the order, so it goes through the
+	// outbox together with the void rather than being lost if Kafka is
+	// down.
+	event := kitchenEvent(t.OrderID, t, fmt.Sprintf("%s ticket voided: %s", t.Station, reason))
+	event.At = timestamppb.Now()
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, err
+	}
+
+	t, err = s.store.VoidTicket(ctx, ticketID, reason, outbox.Message{
+		Topic:   TopicKitchenFailed,
+		Key:     t.OrderID,
+		Payload: payload,
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.WarnContext(ctx, "voided ticket", "order_id", t.OrderID, "ticket_id", t.ID, "station", t.Station, "reason", reason)
+	return t, nil
+}
+
+// publish reports progress on kitchen.accepted, kitchen.preparing and
+// kitchen.ready, for a single ticket or, if ticket is nil, for the whole
+// order. These events only keep the order's status up to date, the order
+// service completes orders from orders.finished, so a failure to publish
+// is logged rather than undoing the step.
+func (s *service) publish(ctx context.Context, topic string, orderID string, ticket *pb.Ticket, reason string) {
+	s.publishEvent(ctx, topic, kitchenEvent(orderID, ticket, reason))
+}
+
+// kitchenEvent describes progress on a single ticket or, if ticket is nil,
+// on the whole order.
+func kitchenEvent(orderID string, ticket *pb.Ticket, reason string) *pb.KitchenEvent {
+	event := &pb.KitchenEvent{
+		OrderID: orderID,
+		Reason:  reason,
+	}
+	if ticket != nil {
+		event.Station = ticket.Station
+		event.TicketID = ticket.ID
+	}
+	return event
+}
+
+func (s *service) publishEvent(ctx context.Context, topic string, event *pb.KitchenEvent) {
+	event.At = timestamppb.Now()
+	if err := s.producer.PublishKitchenEvent(ctx, topic, event); err != nil {
+		slog.WarnContext(ctx, "failed to publish kitchen event", "topic", topic, "order_id", event.OrderID, "error", err)
+	}
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// StationConfig is a kitchen station and the number of tickets it can work
+// on at once.
+type StationConfig struct {
+	Name     string
+	Capacity int
+}
+
+// parseStations reads a comma-separated list of name:capacity pairs, e.g.
+// "grill:2,fryer:2,salad:1,bar:1".
+func parseStations(s string) ([]StationConfig, error) {
+	var stations []StationConfig
+	seen := make(map[string]bool)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		name, capacity, ok := strings.Cut(pair, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid station %q, want name:capacity", pair)
+		}
+
+		name = strings.ToLower(strings.TrimSpace(name))
+		n, err := strconv.Atoi(strings.TrimSpace(capacity))
+		if err != nil || n < 1 {
+			return nil, fmt.Errorf("invalid station %q, capacity must be a positive integer", pair)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("station %q is listed twice", name)
+		}
+		seen[name] = true
+
+		stations = append(stations, StationConfig{Name: name, Capacity: n})
+	}
+
+	if len(stations) == 0 {
+		return nil, fmt.Errorf("no stations configured")
+	}
+	return stations, nil
+}
+
+// Stations splits orders into one ticket per station. Every station works
+// on at most Capacity tickets at a time; the rest wait in its queue, in the
+// order the scheduler puts them.
+type Stations struct {
+	capacities map[string]int
+	// fallback takes the items whose station is unknown.
+	fallback  string
+	scheduler Scheduler
+	clock     Clock
+}
+
+func NewStations(configs []StationConfig, fallback string, scheduler Scheduler, clock Clock) (*Stations, error) {
+	s := &Stations{
+		capacities: make(map[string]int, len(configs)),
+		fallback:   fallback,
+		scheduler:  scheduler,
+		clock:      clock,
+	}
+	for _, c := range configs {
+		s.capacities[c.Name] = c.Capacity
+	}
+
+	if _, ok := s.capacities[fallback]; !ok {
+		return nil, fmt.Errorf("default station %q is not one of the configured stations", fallback)
+	}
+	return s, nil
+}
+
+// Capacity returns how many tickets a station can work on at once, or 0
+// for an unknown station.
+func (s *Stations) Capacity(station string) int {
+	return s.capacities[station]
+}
+
+// Route splits an order into tickets, one per station its items go to.
+func (s *Stations) Route(ctx context.Context, o *pb.Order) []*pb.Ticket {
+	var (
+		tickets   []*pb.Ticket
+		byStation = make(map[string]*pb.Ticket)
+	)
+
+	for _, item := range o.Items {
+		station := item.Station
+		if _, ok := s.capacities[station]; !ok {
+			if station != "" {
+				slog.WarnContext(ctx, "unknown station, using default", "item_id", item.ID, "station", station, "default", s.fallback)
+			}
+			station = s.fallback
+		}
+
+		t, ok := byStation[station]
+		if !ok {
+			t = &pb.Ticket{
+				ID:      o.ID + ":" + station,
+				OrderID: o.ID,
+				Station: station,
+				Status:  pb.TicketStatus_TICKET_QUEUED,
+				VIP:     o.VIP,
+				Rush:    o.Rush,
+			}
+			byStation[station] = t
+			tickets = append(tickets, t)
+		}
+		t.Items = append(t.Items, item)
+	}
+
+	return tickets
+}
+
+// Arrange orders a station's open tickets for its display: the tickets in
+// progress first, by when they were started, then the queued ones in the
+// order they should be started.
+func (s *Stations) Arrange(tickets []*pb.Ticket) []*pb.Ticket {
+	var inProgress, queued []*pb.Ticket
+	for _, t := range tickets {
+		if t.Status == pb.TicketStatus_TICKET_IN_PROGRESS {
+			inProgress = append(inProgress, t)
+		} else {
+			queued = append(queued, t)
+		}
+	}
+
+	slices.SortStableFunc(inProgress, func(a, b *pb.Ticket) int {
+		return cmp.Or(a.StartedAt.AsTime().Compare(b.StartedAt.AsTime()), cmp.Compare(a.ID, b.ID))
+	})
+	s.scheduler.Schedule(s.clock.Now(), queued)
+
+	return append(inProgress, queued...)
+}
@@ -3,54 +3,86 @@ package main
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"fmt"
-	"log"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/migrate"
+	"github.com/kiriyms/oms_go-common/outbox"
 	_ "github.com/mattn/go-sqlite3"
 )
 
 type Store interface {
+	TicketStore
+	PrepTimeStore
+	outbox.Store
 	AcceptOrder(context.Context, *pb.Order) error
-	FinishOrder(context.Context, string) error
+	// FinishOrder marks a ready order as completed, drops its items and
+	// tickets, and enqueues the given events in the same transaction.
+	FinishOrder(ctx context.Context, orderID string, events ...outbox.Message) error
+	CancelOrder(context.Context, string) error
 	GetOrder(context.Context, string) (*pb.Order, error)
 	Close() error
 }
 
+// statusFailed marks orders the kitchen gave up on. It is local to the
+// kitchen; the order service reacts to kitchen.failed instead.
+const statusFailed = "FAILED"
+
 type store struct {
 	db *sql.DB
 }
 
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
 func NewStore(dbPath string) (*store, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := migrate.Open("sqlite3", dbPath, migrationsFS, "migrations")
 	if err != nil {
 		return nil, err
 	}
+	return &store{db: db}, nil
+}
 
-	if err := db.Ping(); err != nil {
-		return nil, err
-	}
-
-	s := &store{db: db}
-	return s, nil
+// RunMigrationCommand prints the pending migrations or rolls back the given
+// number of them without starting the service.
+func RunMigrationCommand(dbPath string, printPending bool, down int) error {
+	return migrate.RunCommand("sqlite3", dbPath, migrationsFS, "migrations", printPending, down)
 }
 
+// AcceptOrder stores a new order. An order delivered again because the
+// kitchen stopped before committing its offset is left as it is, while a
+// canceled, failed or already completed one is rejected.
 func (s *store) AcceptOrder(ctx context.Context, o *pb.Order) error {
-	log.Printf("Accepting order %+v", o)
-
 	tx, err := s.db.Begin()
 	if err != nil {
-		log.Printf("BEGIN FAILED: %v", err)
 		return err
 	}
 	defer tx.Rollback()
 
+	var status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status
+		FROM orders
+		WHERE id = ?
+	`, o.ID).Scan(&status)
+
+	switch {
+	case err == sql.ErrNoRows:
+	case err != nil:
+		return fmt.Errorf("failed to query order: %w", err)
+	case status == pb.OrderStatus_ACCEPTED.String() || status == pb.OrderStatus_PREPARING.String():
+		return nil
+	default:
+		return common.Conflict("order", o.ID, fmt.Sprintf("order %s is already %s", o.ID, status))
+	}
+
 	_, err = tx.Exec(`
 		INSERT INTO orders (id, customer_id, status)
 		VALUES (?, ?, ?)
 	`, o.ID, o.CustomerID, o.Status)
 	if err != nil {
-		log.Printf("ORDER INSERT FAILED: %v", err)
 		return fmt.Errorf("failed to insert order: %w", err)
 	}
 
@@ -59,39 +91,81 @@ func (s *store) AcceptOrder(ctx context.Context, o *pb.Order) error {
 		VALUES (?, ?, ?)
 	`)
 	if err != nil {
-		log.Printf("PREPARE FAILED: %v", err)
 		return fmt.Errorf("failed to prepare order_items stmt: %w", err)
 	}
 	defer stmt.Close()
 
 	for _, item := range o.Items {
 		if item.Quantity <= 0 {
-			log.Printf("INVALID QUANTITY: %d", item.Quantity)
-			return fmt.Errorf("invalid quantity %d for item %s", item.Quantity, item.ID)
+			return common.InvalidArgument("quantity", fmt.Sprintf("%d of item %s is not positive", item.Quantity, item.ID))
 		}
 
 		_, err := stmt.Exec(o.ID, item.ID, item.Quantity)
 		if err != nil {
-			log.Printf("ITEM INSERT FAILED (item=%s): %v", item.ID, err)
 			return fmt.Errorf("failed to insert order item %s: %w", item.ID, err)
 		}
 	}
 
-	log.Printf("COMMITTING ORDER %s", o.ID)
 	if err := tx.Commit(); err != nil {
-		log.Printf("COMMIT FAILED: %v", err)
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	log.Printf("ORDER %s COMMITTED OK", o.ID)
 	return nil
 }
 
-func (s *store) FinishOrder(ctx context.Context, orderID string) error {
-	log.Printf("Finishing order %s", orderID)
+// CancelOrder marks an order none of whose tickets were started as canceled
+// and voids its tickets. If the order has not arrived yet, a canceled
+// placeholder is stored so that the late orders.created event is rejected
+// by AcceptOrder.
+func (s *store) CancelOrder(ctx context.Context, orderID string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
 
+	var status string
+	err = tx.QueryRowContext(ctx, `
+		SELECT status
+		FROM orders
+		WHERE id = ?
+	`, orderID).Scan(&status)
+
+	switch {
+	case err == sql.ErrNoRows:
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO orders (id, customer_id, status)
+			VALUES (?, '', ?)
+		`, orderID, pb.OrderStatus_CANCELED.String())
+		if err != nil {
+			return fmt.Errorf("failed to insert canceled order: %w", err)
+		}
+	case err != nil:
+		return fmt.Errorf("failed to query order: %w", err)
+	case status == pb.OrderStatus_CANCELED.String():
+		return nil
+	case status != pb.OrderStatus_ACCEPTED.String():
+		return common.Conflict("order", orderID, fmt.Sprintf("order %s is already %s", orderID, status))
+	default:
+		_, err = tx.ExecContext(ctx, `
+			UPDATE orders
+			SET status = ?
+			WHERE id = ?
+		`, pb.OrderStatus_CANCELED.String(), orderID)
+		if err != nil {
+			return fmt.Errorf("failed to cancel order: %w", err)
+		}
+		if err := voidOpenTickets(ctx, tx, orderID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *store) FinishOrder(ctx context.Context, orderID string, events ...outbox.Message) error {
 	if orderID == "" {
-		return fmt.Errorf("order ID is required")
+		return common.InvalidArgument("order ID", "is required")
 	}
 
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
@@ -100,7 +174,27 @@ func (s *store) FinishOrder(ctx context.Context, orderID string) error {
 	}
 	defer tx.Rollback()
 
+	// The order itself is kept as COMPLETED, so that AcceptOrder rejects
+	// orders.created if it is delivered again, e.g. by a DLQ replay.
 	res, err := tx.ExecContext(ctx, `
+		UPDATE orders
+		SET status = ?
+		WHERE id = ?
+		  AND status IN (?, ?)
+	`, pb.OrderStatus_COMPLETED.String(), orderID, pb.OrderStatus_ACCEPTED.String(), pb.OrderStatus_PREPARING.String())
+	if err != nil {
+		return fmt.Errorf("failed to complete order: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return common.NotFound("order", orderID)
+	}
+
+	_, err = tx.ExecContext(ctx, `
 		DELETE FROM order_items
 		WHERE order_id = ?
 	`, orderID)
@@ -108,14 +202,34 @@ func (s *store) FinishOrder(ctx context.Context, orderID string) error {
 		return fmt.Errorf("failed to delete order items: %w", err)
 	}
 
-	_, _ = res.RowsAffected()
+	if err := deleteTickets(ctx, tx, orderID); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := outbox.Insert(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
 
-	res, err = tx.ExecContext(ctx, `
-		DELETE FROM orders
+	return nil
+}
+
+// failOrder marks an accepted or started order as failed and voids the
+// tickets still open.
+func failOrder(ctx context.Context, tx *sql.Tx, orderID string) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE orders
+		SET status = ?
 		WHERE id = ?
-	`, orderID)
+		  AND status IN (?, ?)
+	`, statusFailed, orderID, pb.OrderStatus_ACCEPTED.String(), pb.OrderStatus_PREPARING.String())
 	if err != nil {
-		return fmt.Errorf("failed to delete order: %w", err)
+		return fmt.Errorf("failed to fail order: %w", err)
 	}
 
 	affected, err := res.RowsAffected()
@@ -123,19 +237,15 @@ func (s *store) FinishOrder(ctx context.Context, orderID string) error {
 		return err
 	}
 	if affected == 0 {
-		return fmt.Errorf("order %s not found", orderID)
-	}
-
-	if err := tx.Commit(); err != nil {
-		return err
+		return common.Conflict("order", orderID, fmt.Sprintf("order %s is not in the kitchen", orderID))
 	}
 
-	return nil
+	return voidOpenTickets(ctx, tx, orderID)
 }
 
 func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error) {
 	if orderID == "" {
-		return nil, fmt.Errorf("order ID is required")
+		return nil, common.InvalidArgument("order ID", "is required")
 	}
 
 	var (
@@ -151,7 +261,7 @@ func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
 	`, orderID).Scan(&id, &customerID, &status)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("order %s not found", orderID)
+			return nil, common.NotFound("order", orderID)
 		}
 		return nil, fmt.Errorf("failed to query order: %w", err)
 	}
@@ -197,6 +307,18 @@ func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
 	return order, nil
 }
 
+func (s *store) FetchOutbox(ctx context.Context, limit int) ([]outbox.Message, error) {
+	return outbox.Fetch(ctx, s.db, limit)
+}
+
+func (s *store) MarkOutboxPublished(ctx context.Context, id int64) error {
+	return outbox.MarkPublished(ctx, s.db, id)
+}
+
+func (s *store) MarkOutboxFailed(ctx context.Context, id int64, cause error) error {
+	return outbox.MarkFailed(ctx, s.db, id, cause)
+}
+
 func (s *store) Close() error {
 	return s.db.Close()
 }
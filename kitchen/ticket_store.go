@@ -0,0 +1,468 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/outbox"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type TicketStore interface {
+	// CreateTickets stores the tickets of an order. Tickets that already
+	// exist are left as they are, so an order delivered twice is not
+	// prepared twice.
+	CreateTickets(ctx context.Context, tickets []*pb.Ticket) error
+	// ListOpenTickets lists a station's queued and in progress tickets,
+	// oldest first.
+	ListOpenTickets(ctx context.Context, station string) ([]*pb.Ticket, error)
+	GetTicket(ctx context.Context, ticketID string) (*pb.Ticket, error)
+	// StartTicket starts a queued ticket unless its station already has
+	// capacity tickets in progress. Starting an order's first ticket moves
+	// the order to preparing.
+	StartTicket(ctx context.Context, ticketID string, capacity int) (*pb.Ticket, error)
+	// BumpTicket marks a ticket in progress as done. It reports whether all
+	// of the order's tickets are done now.
+	BumpTicket(ctx context.Context, ticketID string) (*pb.Ticket, bool, error)
+	RecallTicket(ctx context.Context, ticketID string) (*pb.Ticket, error)
+	// VoidTicket voids an open ticket and fails its order, voiding the
+	// order's other open tickets with it, and enqueues the given events in
+	// the same transaction.
+	VoidTicket(ctx context.Context, ticketID string, reason string, events ...outbox.Message) (*pb.Ticket, error)
+}
+
+var openTicketStatuses = []pb.TicketStatus{pb.TicketStatus_TICKET_QUEUED, pb.TicketStatus_TICKET_IN_PROGRESS}
+
+const selectTicketsSQL = `
+	SELECT id, order_id, station, status, created_at, started_at, bumped_at, void_reason, vip, rush, prep_ms, fire_at
+	FROM tickets
+`
+
+func (s *store) CreateTickets(ctx context.Context, tickets []*pb.Ticket) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, t := range tickets {
+		var fireAt any
+		if t.FireAt != nil {
+			fireAt = t.FireAt.AsTime().UTC()
+		}
+
+		res, err := tx.ExecContext(ctx, `
+			INSERT INTO tickets (id, order_id, station, status, vip, rush, prep_ms, fire_at)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			ON CONFLICT(id) DO NOTHING
+		`, t.ID, t.OrderID, t.Station, pb.TicketStatus_TICKET_QUEUED.String(), t.VIP, t.Rush, t.PrepTime.AsDuration().Milliseconds(), fireAt)
+		if err != nil {
+			return fmt.Errorf("failed to insert ticket %s: %w", t.ID, err)
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			continue
+		}
+
+		for _, item := range t.Items {
+			_, err := tx.ExecContext(ctx, `
+				INSERT INTO ticket_items (ticket_id, item_id, name, quantity)
+				VALUES (?, ?, ?, ?)
+			`, t.ID, item.ID, item.Name, item.Quantity)
+			if err != nil {
+				return fmt.Errorf("failed to insert ticket item %s: %w", item.ID, err)
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *store) ListOpenTickets(ctx context.Context, station string) ([]*pb.Ticket, error) {
+	rows, err := s.db.QueryContext(ctx, selectTicketsSQL+`
+		WHERE station = ?
+		  AND status IN (?, ?)
+		ORDER BY created_at, id
+	`, station, openTicketStatuses[0].String(), openTicketStatuses[1].String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		tickets []*pb.Ticket
+		byID    = make(map[string]*pb.Ticket)
+	)
+	for rows.Next() {
+		t, err := scanTicket(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket: %w", err)
+		}
+		tickets = append(tickets, t)
+		byID[t.ID] = t
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(tickets) == 0 {
+		return tickets, nil
+	}
+
+	// Fetch the items of all listed tickets at once rather than per ticket.
+	itemRows, err := s.db.QueryContext(ctx, `
+		SELECT ti.ticket_id, ti.item_id, ti.name, ti.quantity
+		FROM ticket_items ti
+		JOIN tickets t ON t.id = ti.ticket_id
+		WHERE t.station = ?
+		  AND t.status IN (?, ?)
+		ORDER BY ti.id
+	`, station, openTicketStatuses[0].String(), openTicketStatuses[1].String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ticket items: %w", err)
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var ticketID string
+		item := &pb.Item{Station: station}
+		if err := itemRows.Scan(&ticketID, &item.ID, &item.Name, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket item: %w", err)
+		}
+		// A ticket that changed status between the two queries is not
+		// listed.
+		if t, ok := byID[ticketID]; ok {
+			t.Items = append(t.Items, item)
+		}
+	}
+
+	return tickets, itemRows.Err()
+}
+
+func (s *store) GetTicket(ctx context.Context, ticketID string) (*pb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	return getTicket(ctx, tx, ticketID)
+}
+
+func (s *store) StartTicket(ctx context.Context, ticketID string, capacity int) (*pb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t, err := getTicket(ctx, tx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Status == pb.TicketStatus_TICKET_QUEUED {
+		var inProgress int
+		err := tx.QueryRowContext(ctx, `
+			SELECT COUNT(*)
+			FROM tickets
+			WHERE station = ?
+			  AND status = ?
+		`, t.Station, pb.TicketStatus_TICKET_IN_PROGRESS.String()).Scan(&inProgress)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count tickets in progress: %w", err)
+		}
+		if inProgress >= capacity {
+			return nil, common.Conflict("station", t.Station, fmt.Sprintf("station %s already has %d tickets in progress", t.Station, inProgress))
+		}
+	}
+
+	err = transitionTicket(ctx, tx, ticketID, []pb.TicketStatus{pb.TicketStatus_TICKET_QUEUED}, pb.TicketStatus_TICKET_IN_PROGRESS,
+		"started_at = CURRENT_TIMESTAMP")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE orders
+		SET status = ?
+		WHERE id = ?
+		  AND status = ?
+	`, pb.OrderStatus_PREPARING.String(), t.OrderID, pb.OrderStatus_ACCEPTED.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to start order: %w", err)
+	}
+
+	return commitTicket(ctx, tx, ticketID)
+}
+
+func (s *store) BumpTicket(ctx context.Context, ticketID string) (*pb.Ticket, bool, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, false, err
+	}
+	defer tx.Rollback()
+
+	err = transitionTicket(ctx, tx, ticketID, []pb.TicketStatus{pb.TicketStatus_TICKET_IN_PROGRESS}, pb.TicketStatus_TICKET_BUMPED,
+		"bumped_at = CURRENT_TIMESTAMP")
+	if err != nil {
+		return nil, false, err
+	}
+
+	var remaining int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM tickets
+		WHERE order_id = (SELECT order_id FROM tickets WHERE id = ?)
+		  AND status != ?
+	`, ticketID, pb.TicketStatus_TICKET_BUMPED.String()).Scan(&remaining)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to count open tickets: %w", err)
+	}
+
+	t, err := commitTicket(ctx, tx, ticketID)
+	if err != nil {
+		return nil, false, err
+	}
+	return t, remaining == 0, nil
+}
+
+func (s *store) RecallTicket(ctx context.Context, ticketID string) (*pb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	t, err := getTicket(ctx, tx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The bumped tickets of a failed order stay bumped.
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM orders WHERE id = ?`, t.OrderID).Scan(&status)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, fmt.Errorf("failed to query order: %w", err)
+	}
+	if status != pb.OrderStatus_ACCEPTED.String() && status != pb.OrderStatus_PREPARING.String() {
+		return nil, common.Conflict("ticket", ticketID, fmt.Sprintf("order %s is no longer in the kitchen", t.OrderID))
+	}
+
+	err = transitionTicket(ctx, tx, ticketID, []pb.TicketStatus{pb.TicketStatus_TICKET_BUMPED}, pb.TicketStatus_TICKET_IN_PROGRESS,
+		"bumped_at = NULL")
+	if err != nil {
+		return nil, err
+	}
+
+	return commitTicket(ctx, tx, ticketID)
+}
+
+func (s *store) VoidTicket(ctx context.Context, ticketID string, reason string, events ...outbox.Message) (*pb.Ticket, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	err = transitionTicket(ctx, tx, ticketID, openTicketStatuses, pb.TicketStatus_TICKET_VOIDED,
+		"void_reason = ?", reason)
+	if err != nil {
+		return nil, err
+	}
+
+	var orderID string
+	if err := tx.QueryRowContext(ctx, `SELECT order_id FROM tickets WHERE id = ?`, ticketID).Scan(&orderID); err != nil {
+		return nil, fmt.Errorf("failed to query ticket: %w", err)
+	}
+	if err := failOrder(ctx, tx, orderID); err != nil {
+		return nil, err
+	}
+
+	for _, e := range events {
+		if err := outbox.Insert(ctx, tx, e); err != nil {
+			return nil, err
+		}
+	}
+
+	return commitTicket(ctx, tx, ticketID)
+}
+
+// transitionTicket moves a ticket in one of the from statuses to status to,
+// applying the extra assignments in set. It fails with a conflict if the
+// ticket is in none of from.
+func transitionTicket(ctx context.Context, tx *sql.Tx, ticketID string, from []pb.TicketStatus, to pb.TicketStatus, set string, args ...any) error {
+	var (
+		placeholders = make([]string, len(from))
+		names        = make([]string, len(from))
+		fromArgs     = make([]any, len(from))
+	)
+	for i, st := range from {
+		placeholders[i] = "?"
+		names[i] = ticketStatusName(st.String())
+		fromArgs[i] = st.String()
+	}
+
+	args = append([]any{to.String()}, args...)
+	args = append(args, ticketID)
+	args = append(args, fromArgs...)
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE tickets
+		SET status = ?, `+set+`
+		WHERE id = ?
+		  AND status IN (`+strings.Join(placeholders, ", ")+`)
+	`, args...)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected > 0 {
+		return nil
+	}
+
+	var status string
+	err = tx.QueryRowContext(ctx, `SELECT status FROM tickets WHERE id = ?`, ticketID).Scan(&status)
+	if err == sql.ErrNoRows {
+		return common.NotFound("ticket", ticketID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to query ticket: %w", err)
+	}
+	return common.Conflict("ticket", ticketID, fmt.Sprintf("ticket %s is %s, not %s", ticketID, ticketStatusName(status), strings.Join(names, " or ")))
+}
+
+// voidOpenTickets voids the tickets of an order that are not done yet.
+func voidOpenTickets(ctx context.Context, tx *sql.Tx, orderID string) error {
+	_, err := tx.ExecContext(ctx, `
+		UPDATE tickets
+		SET status = ?
+		WHERE order_id = ?
+		  AND status IN (?, ?)
+	`, pb.TicketStatus_TICKET_VOIDED.String(), orderID, openTicketStatuses[0].String(), openTicketStatuses[1].String())
+	if err != nil {
+		return fmt.Errorf("failed to void tickets: %w", err)
+	}
+	return nil
+}
+
+func deleteTickets(ctx context.Context, tx *sql.Tx, orderID string) error {
+	_, err := tx.ExecContext(ctx, `
+		DELETE FROM ticket_items
+		WHERE ticket_id IN (SELECT id FROM tickets WHERE order_id = ?)
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to delete ticket items: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM tickets
+		WHERE order_id = ?
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to delete tickets: %w", err)
+	}
+	return nil
+}
+
+// commitTicket reads a ticket back and commits tx.
+func commitTicket(ctx context.Context, tx *sql.Tx, ticketID string) (*pb.Ticket, error) {
+	t, err := getTicket(ctx, tx, ticketID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return t, nil
+}
+
+func getTicket(ctx context.Context, tx *sql.Tx, ticketID string) (*pb.Ticket, error) {
+	t, err := scanTicket(tx.QueryRowContext(ctx, selectTicketsSQL+`
+		WHERE id = ?
+	`, ticketID))
+	if err == sql.ErrNoRows {
+		return nil, common.NotFound("ticket", ticketID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ticket: %w", err)
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT item_id, name, quantity
+		FROM ticket_items
+		WHERE ticket_id = ?
+		ORDER BY id
+	`, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch ticket items: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		item := &pb.Item{Station: t.Station}
+		if err := rows.Scan(&item.ID, &item.Name, &item.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket item: %w", err)
+		}
+		t.Items = append(t.Items, item)
+	}
+
+	return t, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTicket(row rowScanner) (*pb.Ticket, error) {
+	var (
+		t         pb.Ticket
+		status    string
+		createdAt time.Time
+		startedAt sql.NullTime
+		bumpedAt  sql.NullTime
+		prepMs    int64
+		fireAt    sql.NullTime
+	)
+
+	err := row.Scan(&t.ID, &t.OrderID, &t.Station, &status, &createdAt, &startedAt, &bumpedAt, &t.VoidReason,
+		&t.VIP, &t.Rush, &prepMs, &fireAt)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Status = pb.TicketStatus(pb.TicketStatus_value[status])
+	t.CreatedAt = timestamppb.New(createdAt)
+	if startedAt.Valid {
+		t.StartedAt = timestamppb.New(startedAt.Time)
+	}
+	if bumpedAt.Valid {
+		t.BumpedAt = timestamppb.New(bumpedAt.Time)
+	}
+	// Tickets queued before estimates were kept have no prep time.
+	if prepMs > 0 {
+		t.PrepTime = durationpb.New(time.Duration(prepMs) * time.Millisecond)
+	}
+	if fireAt.Valid {
+		t.FireAt = timestamppb.New(fireAt.Time)
+	}
+	return &t, nil
+}
+
+// ticketStatusName turns e.g. TICKET_IN_PROGRESS into "in progress" for
+// error messages.
+func ticketStatusName(status string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimPrefix(status, "TICKET_"), "_", " "))
+}
@@ -0,0 +1,85 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// restockOrder gives back the stock of an order that had status when it was
+// canceled or failed. Until the saga finalizes the booking the stock is only
+// booked, and afterwards it has been taken out of the stock items, so paid
+// orders have theirs returned instead of released. A pending order's booking
+// may have been finalized just before the order was canceled, so it gets
+// both; each is a no-op when there is nothing to give back.
+func restockOrder(ctx context.Context, stockClient pb.StockServiceClient, o *pb.Order, status pb.OrderStatus) error {
+	if status == pb.OrderStatus_PENDING {
+		if err := releaseOrderBooking(ctx, stockClient, o); err != nil {
+			return err
+		}
+	}
+
+	resp, err := stockClient.ReturnFinalizedItems(ctx, &pb.ReturnFinalizedItemsRequest{OrderID: o.ID})
+	if err != nil {
+		return fmt.Errorf("failed to return stock: %w", err)
+	}
+	if len(resp.Items) > 0 {
+		slog.InfoContext(ctx, "returned stock", "order_id", o.ID, "items", len(resp.Items))
+	}
+	return nil
+}
+
+// releaseOrderBooking drops every stock booking the order still holds.
+// Releasing with zero quantities removes whatever is booked per item, which
+// also covers partial bookings and makes the call safe to repeat.
+func releaseOrderBooking(ctx context.Context, stockClient pb.StockServiceClient, o *pb.Order) error {
+	items := mergeItemsQuantities(mapItemToItemWithQuantity(o.Items))
+	for _, item := range items {
+		item.Quantity = 0
+	}
+
+	_, err := stockClient.ReleaseBookedItems(ctx, &pb.ReleaseBookedItemsRequest{
+		OrderID: o.ID,
+		Items:   items,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to release booking: %w", err)
+	}
+	return nil
+}
+
+// refundOrderPayment refunds whatever is left of the order's captured
+// payment, and voids a pending one so that it can no longer be captured. It
+// reports whether money was returned; orders without a payment are left
+// alone.
+func refundOrderPayment(ctx context.Context, paymentClient pb.PaymentServiceClient, orderID string) (bool, error) {
+	resp, err := paymentClient.GetPayment(ctx, &pb.GetPaymentRequest{OrderID: orderID})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to load payment of order %s: %w", orderID, err)
+	}
+
+	switch resp.Payment.Status {
+	case pb.PaymentStatus_PAYMENT_PENDING.String():
+		_, err = paymentClient.VoidPayment(ctx, &pb.VoidPaymentRequest{PaymentID: resp.Payment.ID})
+		if err != nil {
+			return false, fmt.Errorf("failed to void payment %s: %w", resp.Payment.ID, err)
+		}
+		return false, nil
+	case pb.PaymentStatus_PAYMENT_CAPTURED.String():
+	default:
+		return false, nil
+	}
+
+	_, err = paymentClient.RefundPayment(ctx, &pb.RefundPaymentRequest{PaymentID: resp.Payment.ID})
+	if err != nil {
+		return false, fmt.Errorf("failed to refund payment %s: %w", resp.Payment.ID, err)
+	}
+	return true, nil
+}
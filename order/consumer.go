@@ -0,0 +1,285 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
+	"github.com/segmentio/kafka-go"
+)
+
+// consumerMaxBackoff caps the wait between retries of an event.
+const consumerMaxBackoff = time.Minute
+
+type Consumer struct {
+	reader  *kafka.Reader
+	service OrderService
+}
+
+func NewConsumer(brokerURL string, groupID string, service OrderService) *Consumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  []string{brokerURL},
+		Topic:    TopicOrderFinished,
+		GroupID:  groupID,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+
+	return &Consumer{reader: reader, service: service}
+}
+
+// Start reads orders.finished events and completes the matching orders.
+// Offsets are committed only once an event was handled or turned out to be
+// one that cannot be, so a crash leads to redelivery; CompleteOrder is
+// idempotent, which makes that harmless.
+func (c *Consumer) Start(ctx context.Context) {
+	slog.Info("starting consumer", "topic", TopicOrderFinished)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to read message, retrying in 5s", "error", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		if !c.handle(consumerContext(ctx, msg), msg) {
+			// Only happens on shutdown; the message is delivered again
+			// after a restart.
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			slog.Error("failed to commit message", "error", err)
+		}
+	}
+}
+
+func (c *Consumer) handle(ctx context.Context, msg kafka.Message) bool {
+	var event pb.Order
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal event", "error", err)
+		return true
+	}
+
+	slog.InfoContext(ctx, "received finished order", "order_id", event.ID)
+
+	return retryEvent(ctx, msg.Topic, event.ID, func() error {
+		_, err := c.service.CompleteOrder(ctx, event.ID)
+		return err
+	})
+}
+
+// retryEvent runs handle until it succeeds or fails with an error that a
+// retry would not resolve, e.g. a conflict. Any other failure, such as the
+// stock or payment service being down, is retried with exponential backoff
+// for as long as it lasts, so that the event is not committed unhandled. It
+// reports whether the event is done with, which it is not only when ctx is
+// canceled first.
+func retryEvent(ctx context.Context, topic string, orderID string, handle func() error) bool {
+	backoff := time.Second
+	for attempt := 1; ctx.Err() == nil; attempt++ {
+		err := handle()
+		if err == nil {
+			return true
+		}
+
+		if !retryable(err) {
+			slog.WarnContext(ctx, "skipping event", "topic", topic, "order_id", orderID, "error", err)
+			return true
+		}
+
+		slog.ErrorContext(ctx, "failed to handle event, retrying", "topic", topic, "order_id", orderID, "attempt", attempt, "retry_in", backoff, "error", err)
+		select {
+		case <-ctx.Done():
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, consumerMaxBackoff)
+	}
+	return false
+}
+
+// retryable reports whether err may go away on a retry. Orders in the wrong
+// state stay there, and unknown or invalid ones stay unknown or invalid.
+func retryable(err error) bool {
+	err = common.FromStatus(err)
+	var (
+		conflict *common.ConflictError
+		notFound *common.NotFoundError
+		invalid  *common.InvalidArgumentError
+	)
+	return !errors.As(err, &conflict) && !errors.As(err, &notFound) && !errors.As(err, &invalid)
+}
+
+// KitchenConsumer follows orders through the kitchen: it moves them along
+// as the kitchen reports progress and refunds those the kitchen failed.
+type KitchenConsumer struct {
+	reader  *kafka.Reader
+	service OrderService
+}
+
+// kitchenTargets maps the kitchen progress topics to the order status they
+// imply.
+var kitchenTargets = map[string]pb.OrderStatus{
+	TopicKitchenAccepted:  pb.OrderStatus_ACCEPTED,
+	TopicKitchenPreparing: pb.OrderStatus_PREPARING,
+	TopicKitchenReady:     pb.OrderStatus_READY,
+}
+
+func NewKitchenConsumer(brokerURL string, groupID string, service OrderService) *KitchenConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:     []string{brokerURL},
+		GroupTopics: []string{TopicKitchenAccepted, TopicKitchenPreparing, TopicKitchenReady, TopicKitchenFailed},
+		GroupID:     groupID,
+		MinBytes:    10e3,
+		MaxBytes:    10e6,
+	})
+
+	return &KitchenConsumer{reader: reader, service: service}
+}
+
+// Start reads the kitchen.* events. Like Consumer, it commits offsets only
+// after handling an event; AdvanceOrder and FailOrder are both idempotent.
+func (c *KitchenConsumer) Start(ctx context.Context) {
+	slog.Info("starting consumer", "topics", c.reader.Config().GroupTopics)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to read message, retrying in 5s", "error", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		if !c.handle(consumerContext(ctx, msg), msg) {
+			// Only happens on shutdown; the message is delivered again
+			// after a restart.
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			slog.Error("failed to commit message", "error", err)
+		}
+	}
+}
+
+func (c *KitchenConsumer) handle(ctx context.Context, msg kafka.Message) bool {
+	var event pb.KitchenEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal event", "error", err)
+		return true
+	}
+
+	slog.InfoContext(ctx, "received kitchen event", "topic", msg.Topic, "order_id", event.OrderID, "station", event.Station)
+
+	// Order-wide events carry no station.
+	triggeredBy := "kitchen"
+	if event.Station != "" {
+		triggeredBy += ":" + event.Station
+	}
+	return retryEvent(ctx, msg.Topic, event.OrderID, func() error {
+		if msg.Topic == TopicKitchenFailed {
+			_, err := c.service.FailOrder(ctx, event.OrderID, event.Reason, triggeredBy)
+			return err
+		}
+		if _, err := c.service.AdvanceOrder(ctx, event.OrderID, kitchenTargets[msg.Topic], triggeredBy); err != nil {
+			return err
+		}
+		if event.EstimatedReadyAt != nil {
+			return c.service.SetEstimatedReadyAt(ctx, event.OrderID, event.EstimatedReadyAt.AsTime())
+		}
+		return nil
+	})
+}
+
+func (c *KitchenConsumer) Close() error {
+	return c.reader.Close()
+}
+
+// BookingConsumer hands orders whose stock booking expired to the saga
+// coordinator, so that they are canceled rather than left waiting for a
+// payment that no stock is held for.
+type BookingConsumer struct {
+	reader *kafka.Reader
+	saga   *SagaCoordinator
+}
+
+func NewBookingConsumer(brokerURL string, groupID string, saga *SagaCoordinator) *BookingConsumer {
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers:  []string{brokerURL},
+		Topic:    TopicBookingExpired,
+		GroupID:  groupID,
+		MinBytes: 10e3,
+		MaxBytes: 10e6,
+	})
+
+	return &BookingConsumer{reader: reader, saga: saga}
+}
+
+// Start reads stock.booking_expired events. Like Consumer, it commits
+// offsets only after handling an event; ExpireBooking is idempotent.
+func (c *BookingConsumer) Start(ctx context.Context) {
+	slog.Info("starting consumer", "topic", TopicBookingExpired)
+	for {
+		msg, err := c.reader.FetchMessage(ctx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			slog.Error("failed to read message, retrying in 5s", "error", err)
+			time.Sleep(5 * time.Second)
+			continue
+		}
+
+		if !c.handle(consumerContext(ctx, msg), msg) {
+			// Only happens on shutdown; the message is delivered again
+			// after a restart.
+			return
+		}
+
+		if err := c.reader.CommitMessages(ctx, msg); err != nil {
+			slog.Error("failed to commit message", "error", err)
+		}
+	}
+}
+
+func (c *BookingConsumer) handle(ctx context.Context, msg kafka.Message) bool {
+	var event pb.BookingExpiredEvent
+	if err := json.Unmarshal(msg.Value, &event); err != nil {
+		slog.ErrorContext(ctx, "failed to unmarshal event", "error", err)
+		return true
+	}
+
+	slog.InfoContext(ctx, "received expired booking", "order_id", event.OrderID)
+
+	return retryEvent(ctx, msg.Topic, event.OrderID, func() error {
+		return c.saga.ExpireBooking(ctx, event.OrderID)
+	})
+}
+
+func (c *BookingConsumer) Close() error {
+	return c.reader.Close()
+}
+
+// consumerContext carries the correlation ID of msg, if it has one.
+func consumerContext(ctx context.Context, msg kafka.Message) context.Context {
+	for _, h := range msg.Headers {
+		if h.Key == logging.CorrelationHeader {
+			return logging.WithCorrelationID(ctx, string(h.Value))
+		}
+	}
+	return logging.EnsureCorrelationID(ctx, "")
+}
+
+func (c *Consumer) Close() error {
+	return c.reader.Close()
+}
@@ -2,72 +2,100 @@ package main
 
 import (
 	"context"
-	"log"
+	"log/slog"
 
 	"github.com/google/uuid"
 	pb "github.com/kiriyms/oms_go-common/api"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
 )
 
 type Handler struct {
 	pb.UnimplementedOrderServiceServer
 	service     OrderService
 	stockClient pb.StockServiceClient
-	producer    *Producer
 }
 
-func NewHandler(s *grpc.Server, service OrderService, stockClient pb.StockServiceClient, producer *Producer) *Handler {
+func NewHandler(s *grpc.Server, service OrderService, stockClient pb.StockServiceClient) *Handler {
 	h := &Handler{
 		service:     service,
 		stockClient: stockClient,
-		producer:    producer,
 	}
 	pb.RegisterOrderServiceServer(s, h)
 	return h
 }
 
 func (h *Handler) CreateOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
-	log.Printf("New order received: %v", p)
+	slog.InfoContext(ctx, "new order received", "customer_id", p.CustomerID, "items", len(p.Items))
+
+	// A retry must not be validated again: the original request's stock may
+	// be booked by now.
+	if o, err := h.service.ReplayOrder(ctx, p); err != nil || o != nil {
+		return o, err
+	}
+
 	if err := h.service.ValidateOrder(ctx, p); err != nil {
 		return nil, err
 	}
+
+	return h.service.CreateOrder(ctx, h.newOrder(p), p)
+}
+
+// QuoteOrder returns the order CreateOrder would place for p, priced but
+// without an ID, status or booked stock.
+func (h *Handler) QuoteOrder(ctx context.Context, p *pb.CreateOrderRequest) (*pb.Order, error) {
 	o := &pb.Order{
-		ID:         uuid.New().String(),
 		CustomerID: p.CustomerID,
-		Status:     "PENDING",
 		Items:      h.mapItemWithQuantityToItem(p.Items),
 	}
 
-	err := h.service.CreateOrder(ctx, o)
-	if err != nil {
-		return nil, err
-	}
-
-	err = h.producer.PublishOrderCreated(ctx, o)
-	if err != nil {
-		log.Printf("Failed to publish order.created event: %v", err)
+	if err := h.service.QuoteOrder(ctx, o, p.PromoCode); err != nil {
 		return nil, err
 	}
 
 	return o, nil
 }
 
+func (h *Handler) newOrder(p *pb.CreateOrderRequest) *pb.Order {
+	return &pb.Order{
+		ID:         uuid.New().String(),
+		CustomerID: p.CustomerID,
+		Status:     pb.OrderStatus_PENDING.String(),
+		Items:      h.mapItemWithQuantityToItem(p.Items),
+		VIP:        p.VIP,
+		Rush:       p.Rush,
+	}
+}
+
 func (h *Handler) GetOrder(ctx context.Context, p *pb.GetOrderRequest) (*pb.Order, error) {
 	return h.service.GetOrder(ctx, p.ID)
 }
 
 func (h *Handler) GetUserOrders(ctx context.Context, p *pb.GetUserOrdersRequest) (*pb.GetUserOrdersResponse, error) {
-	orders, err := h.service.GetUserOrders(ctx, p.CustomerID)
-	if err != nil {
-		return nil, err
-	}
-	return &pb.GetUserOrdersResponse{
-		Orders: orders,
-	}, nil
+	return h.service.GetUserOrders(ctx, p)
 }
 
 func (h *Handler) PatchOrderStatus(ctx context.Context, p *pb.PatchOrderStatusRequest) (*pb.Order, error) {
-	return h.service.PatchOrderStatus(ctx, p.OrderID, p.Status)
+	return h.service.PatchOrderStatus(ctx, p)
+}
+
+func (h *Handler) CancelOrder(ctx context.Context, p *pb.CancelOrderRequest) (*pb.Order, error) {
+	return h.service.CancelOrder(ctx, p)
+}
+
+func (h *Handler) WatchOrder(req *pb.WatchOrderRequest, stream pb.OrderService_WatchOrderServer) error {
+	ctx := stream.Context()
+	if _, err := h.service.GetOrder(ctx, req.OrderID); err != nil {
+		return err
+	}
+
+	// Send the headers right away so that clients can tell an established
+	// watch from a failed one before the first change arrives.
+	if err := stream.SendHeader(metadata.MD{}); err != nil {
+		return err
+	}
+
+	return h.service.WatchOrder(ctx, req, stream.Send)
 }
 
 func (h *Handler) mapItemWithQuantityToItem(iwq []*pb.ItemWithQuantity) []*pb.Item {
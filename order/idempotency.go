@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"time"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/proto"
+)
+
+// maxIdempotencyKeyLength bounds client supplied keys, which are stored as
+// part of the primary key of idempotency_keys.
+const maxIdempotencyKeyLength = 255
+
+// errIdempotencyKeyTaken is returned by Create when another request already
+// claimed the idempotency key, e.g. a retry racing the original.
+var errIdempotencyKeyTaken = errors.New("idempotency key already used")
+
+// IdempotencyRecord is the outcome of a CreateOrder call made with an
+// idempotency key. Keys are scoped to the customer.
+type IdempotencyRecord struct {
+	CustomerID  string
+	Key         string
+	RequestHash string
+	Order       *pb.Order
+	ExpiresAt   time.Time
+}
+
+type IdempotencyStore interface {
+	// GetIdempotencyRecord returns NotFound for unknown or expired keys.
+	GetIdempotencyRecord(ctx context.Context, customerID, key string) (*IdempotencyRecord, error)
+}
+
+// requestHash fingerprints a CreateOrderRequest, so that a key reused for a
+// different request can be told apart from a retry.
+func requestHash(req *pb.CreateOrderRequest) (string, error) {
+	req = proto.CloneOf(req)
+	req.IdempotencyKey = ""
+
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(req)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:]), nil
+}
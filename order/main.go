@@ -1,54 +1,165 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	"log/slog"
 	"net"
+	"time"
 
 	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
+	"github.com/kiriyms/oms_go-common/outbox"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 var (
-	grpcAddr         = common.GetEnv("GRPC_ADDR", "localhost:50051")
-	dbPath           = common.GetEnv("DB_PATH", "./db/db.db")
-	stockServiceAddr = common.GetEnv("STOCK_SERVICE_ADDR", "localhost:50052")
-	brokerURL        = common.GetEnv("KAFKA_BROKER_URL", "localhost:9092")
+	grpcAddr           = common.GetEnv("GRPC_ADDR", "localhost:50051")
+	dbPath             = common.GetEnv("DB_PATH", "./db/db.db")
+	stockServiceAddr   = common.GetEnv("STOCK_SERVICE_ADDR", "localhost:50052")
+	paymentServiceAddr = common.GetEnv("PAYMENT_SERVICE_ADDR", "localhost:50053")
+	pricingServiceAddr = common.GetEnv("PRICING_SERVICE_ADDR", "localhost:50052")
+	brokerURL          = common.GetEnv("KAFKA_BROKER_URL", "localhost:9092")
+	paymentTimeout     = common.GetEnv("PAYMENT_TIMEOUT", "10m")
+	taxRate            = common.GetEnv("TAX_RATE", "0")
+	promoCodes         = common.GetEnv("PROMO_CODES", "")
+	idempotencyTTL     = common.GetEnv("IDEMPOTENCY_TTL", "24h")
+)
+
+const (
+	outboxInterval = time.Second
+	sagaInterval   = 2 * time.Second
+	feedInterval   = 500 * time.Millisecond
+)
+
+var (
+	printPendingMigrations = flag.Bool("pending-migrations", false, "print pending database migrations and exit")
+	migrateDown            = flag.Int("migrate-down", 0, "roll back the given number of database migrations and exit")
 )
 
 func main() {
-	stockConn, err := grpc.NewClient(stockServiceAddr, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	logging.Init("order")
+
+	flag.Parse()
+	if *printPendingMigrations || *migrateDown > 0 {
+		if err := RunMigrationCommand(dbPath, *printPendingMigrations, *migrateDown); err != nil {
+			logging.Fatal("migration command failed", "error", err)
+		}
+		return
+	}
+
+	stockConn, err := grpc.NewClient(stockServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
 	if err != nil {
-		log.Fatalf("Failed to connect to Stock Service: %v", err)
+		logging.Fatal("failed to connect to stock service", "error", err)
 	}
 	defer stockConn.Close()
-	log.Println("Dialed Stock Service at ", stockServiceAddr)
+	slog.Info("dialed stock service", "addr", stockServiceAddr)
 
 	stockC := pb.NewStockServiceClient(stockConn)
 
-	grpcServer := grpc.NewServer()
+	paymentConn, err := grpc.NewClient(paymentServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal("failed to connect to payment service", "error", err)
+	}
+	defer paymentConn.Close()
+	slog.Info("dialed payment service", "addr", paymentServiceAddr)
+
+	paymentC := pb.NewPaymentServiceClient(paymentConn)
+
+	// The pricing service is served by the stock service by default, but may
+	// live elsewhere.
+	pricingConn, err := grpc.NewClient(pricingServiceAddr,
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(logging.UnaryClientInterceptor()),
+		grpc.WithStreamInterceptor(logging.StreamClientInterceptor()),
+	)
+	if err != nil {
+		logging.Fatal("failed to connect to pricing service", "error", err)
+	}
+	defer pricingConn.Close()
+	slog.Info("dialed pricing service", "addr", pricingServiceAddr)
+
+	pricingC := pb.NewPricingServiceClient(pricingConn)
+
+	timeout, err := time.ParseDuration(paymentTimeout)
+	if err != nil {
+		logging.Fatal("invalid PAYMENT_TIMEOUT", "error", err)
+	}
+
+	tax, err := parseRate(taxRate)
+	if err != nil {
+		logging.Fatal("invalid TAX_RATE", "error", err)
+	}
+
+	promos, err := parsePromoCodes(promoCodes)
+	if err != nil {
+		logging.Fatal("invalid PROMO_CODES", "error", err)
+	}
+
+	idemTTL, err := time.ParseDuration(idempotencyTTL)
+	if err != nil {
+		logging.Fatal("invalid IDEMPOTENCY_TTL", "error", err)
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), common.UnaryErrorInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), common.StreamErrorInterceptor()),
+	)
 	l, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logging.Fatal("failed to listen", "error", err)
 	}
 	defer l.Close()
 
 	store, err := NewStore(dbPath)
 	if err != nil {
-		log.Fatalf("Failed to create store: %v", err)
+		logging.Fatal("failed to create store", "error", err)
 	}
 	defer store.Close()
 
 	producer := NewProducer(brokerURL)
 	defer producer.Close()
 
-	service := NewOrderService(store, stockC)
-	NewHandler(grpcServer, service, stockC, producer)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	relay := outbox.NewRelay(store, producer, outboxInterval)
+	go relay.Start(ctx)
+
+	saga := NewSagaCoordinator(store, stockC, paymentC, timeout, sagaInterval)
+	go saga.Start(ctx)
+
+	feed := NewStatusFeed(store, feedInterval)
+	go feed.Start(ctx)
+
+	service := NewOrderService(store, stockC, paymentC, pricingC, saga, feed, PricingConfig{TaxRate: tax, PromoCodes: promos}, idemTTL)
+	NewHandler(grpcServer, service, stockC)
+
+	consumer := NewConsumer(brokerURL, "order-service-finished", service)
+	defer consumer.Close()
+	go consumer.Start(ctx)
+
+	kitchenConsumer := NewKitchenConsumer(brokerURL, "order-service-kitchen", service)
+	defer kitchenConsumer.Close()
+	go kitchenConsumer.Start(ctx)
+
+	bookingConsumer := NewBookingConsumer(brokerURL, "order-service-bookings", saga)
+	defer bookingConsumer.Close()
+	go bookingConsumer.Start(ctx)
 
-	log.Println("gRPC server listening on", grpcAddr)
+	slog.Info("grpc server listening", "addr", grpcAddr)
 
 	if err := grpcServer.Serve(l); err != nil {
-		log.Fatal(err.Error())
+		logging.Fatal("grpc server stopped", "error", err)
 	}
 }
@@ -0,0 +1,19 @@
+-- Before migrations, the orders and order_items tables were created by hand
+-- in ./db/db.db. This baseline adopts them where they already exist.
+CREATE TABLE IF NOT EXISTS orders (
+    id          TEXT PRIMARY KEY,
+    customer_id TEXT NOT NULL,
+    status      TEXT NOT NULL,
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_customer_id ON orders (customer_id, created_at);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id       INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    item_id  TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
@@ -0,0 +1,11 @@
+CREATE TABLE order_status_history (
+    id           INTEGER PRIMARY KEY AUTOINCREMENT,
+    order_id     TEXT NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    from_status  TEXT NOT NULL,
+    to_status    TEXT NOT NULL,
+    triggered_by TEXT NOT NULL,
+    reason       TEXT NOT NULL DEFAULT '',
+    created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_order_status_history_order_id ON order_status_history (order_id);
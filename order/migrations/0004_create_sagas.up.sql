@@ -0,0 +1,12 @@
+CREATE TABLE sagas (
+    order_id   TEXT PRIMARY KEY REFERENCES orders (id) ON DELETE CASCADE,
+    state      TEXT NOT NULL,
+    payment_id TEXT NOT NULL DEFAULT '',
+    attempts   INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT NOT NULL DEFAULT '',
+    deadline   DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_sagas_state ON sagas (state);
@@ -0,0 +1,2 @@
+ALTER TABLE sagas DROP COLUMN correlation_id;
+ALTER TABLE outbox DROP COLUMN correlation_id;
@@ -0,0 +1,2 @@
+ALTER TABLE outbox ADD COLUMN correlation_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE sagas ADD COLUMN correlation_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+ALTER TABLE order_items ADD COLUMN name TEXT NOT NULL DEFAULT '';
+ALTER TABLE order_items ADD COLUMN price_id TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,7 @@
+ALTER TABLE order_items DROP COLUMN unit_price;
+
+ALTER TABLE orders DROP COLUMN total;
+ALTER TABLE orders DROP COLUMN tax;
+ALTER TABLE orders DROP COLUMN discount;
+ALTER TABLE orders DROP COLUMN subtotal;
+ALTER TABLE orders DROP COLUMN currency;
@@ -0,0 +1,7 @@
+ALTER TABLE orders ADD COLUMN currency TEXT NOT NULL DEFAULT '';
+ALTER TABLE orders ADD COLUMN subtotal INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN discount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN tax INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN total INTEGER NOT NULL DEFAULT 0;
+
+ALTER TABLE order_items ADD COLUMN unit_price INTEGER NOT NULL DEFAULT 0;
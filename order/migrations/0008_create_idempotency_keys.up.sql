@@ -0,0 +1,12 @@
+CREATE TABLE idempotency_keys (
+    customer_id  TEXT NOT NULL,
+    key          TEXT NOT NULL,
+    request_hash TEXT NOT NULL,
+    order_id     TEXT NOT NULL,
+    response     BLOB NOT NULL,
+    created_at   DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at   DATETIME NOT NULL,
+    PRIMARY KEY (customer_id, key)
+);
+
+CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys (expires_at);
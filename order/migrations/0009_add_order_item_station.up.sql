@@ -0,0 +1 @@
+ALTER TABLE order_items ADD COLUMN station TEXT NOT NULL DEFAULT '';
@@ -0,0 +1 @@
+ALTER TABLE orders ADD COLUMN estimated_ready_at DATETIME;
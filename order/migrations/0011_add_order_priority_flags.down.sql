@@ -0,0 +1,2 @@
+ALTER TABLE orders DROP COLUMN rush;
+ALTER TABLE orders DROP COLUMN vip;
@@ -0,0 +1,2 @@
+ALTER TABLE orders ADD COLUMN vip INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE orders ADD COLUMN rush INTEGER NOT NULL DEFAULT 0;
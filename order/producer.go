@@ -2,45 +2,56 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"log"
+	"log/slog"
 
-	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
 	"github.com/segmentio/kafka-go"
 )
 
+const (
+	TopicOrderCreated  = "orders.created"
+	TopicOrderFinished = "orders.finished"
+	TopicOrderCanceled = "orders.canceled"
+
+	TopicKitchenAccepted  = "kitchen.accepted"
+	TopicKitchenPreparing = "kitchen.preparing"
+	TopicKitchenReady     = "kitchen.ready"
+	TopicKitchenFailed    = "kitchen.failed"
+
+	TopicBookingExpired = "stock.booking_expired"
+)
+
 type Producer struct {
 	writer *kafka.Writer
 }
 
 func NewProducer(brokerURL string) *Producer {
-	writer := kafka.NewWriter(kafka.WriterConfig{
-		Brokers:  []string{brokerURL},
-		Topic:    "orders.created",
-		Balancer: &kafka.LeastBytes{},
-	})
+	writer := &kafka.Writer{
+		Addr:                   kafka.TCP(brokerURL),
+		Balancer:               &kafka.LeastBytes{},
+		AllowAutoTopicCreation: true,
+	}
 
 	return &Producer{writer: writer}
 }
 
-func (p *Producer) PublishOrderCreated(ctx context.Context, order *pb.Order) error {
-	valueBytes, err := json.Marshal(order)
-	if err != nil {
-		return err
-	}
-
+func (p *Producer) Publish(ctx context.Context, topic string, key string, value []byte) error {
 	msg := kafka.Message{
-		Key:   []byte(order.ID),
-		Value: valueBytes,
+		Topic: topic,
+		Key:   []byte(key),
+		Value: value,
+		Headers: []kafka.Header{
+			{Key: logging.CorrelationHeader, Value: []byte(logging.CorrelationID(ctx))},
+		},
 	}
 
-	err = p.writer.WriteMessages(ctx, msg)
+	err := p.writer.WriteMessages(ctx, msg)
 	if err != nil {
-		log.Printf("failed to write message: %v", err)
+		slog.ErrorContext(ctx, "failed to write message", "topic", topic, "error", err)
 		return err
 	}
 
-	log.Printf("published order.created event: %s", order.ID)
+	slog.InfoContext(ctx, "published event", "topic", topic, "key", key)
 	return nil
 }
 
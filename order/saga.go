@@ -0,0 +1,413 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
+	"github.com/kiriyms/oms_go-common/money"
+	"github.com/kiriyms/oms_go-common/outbox"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Saga states. A create-order saga books stock, waits for the customer's
+// payment to be captured, finalizes the booking and only then hands the
+// order to the kitchen. Any failure on the way gives the stock back,
+// refunds a captured payment and cancels the order.
+const (
+	SagaStarted      = "STARTED"
+	SagaStockBooked  = "STOCK_BOOKED"
+	SagaPaid         = "PAID"
+	SagaCompensating = "COMPENSATING"
+	SagaCompleted    = "COMPLETED"
+	SagaAborted      = "ABORTED"
+)
+
+const (
+	sagaTriggeredBy   = "order-saga"
+	sagaMaxAttempts   = 10
+	sagaKickQueueSize = 64
+)
+
+// Saga is the persisted progress of a single create-order saga.
+type Saga struct {
+	OrderID   string
+	State     string
+	PaymentID string
+	Attempts  int
+	LastError string
+	Deadline  time.Time
+	// CorrelationID ties the saga's log lines and events to the request
+	// that created the order.
+	CorrelationID string
+}
+
+type SagaStore interface {
+	ListActiveSagas(ctx context.Context) ([]Saga, error)
+	GetSaga(ctx context.Context, orderID string) (Saga, error)
+	// UpdateSaga persists sg if the stored saga is still in state from and
+	// fails otherwise, e.g. when the order was canceled in the meantime.
+	UpdateSaga(ctx context.Context, from string, sg Saga) error
+	// FinishSaga moves the order and the saga to their final states and
+	// enqueues the given events in a single transaction.
+	FinishSaga(ctx context.Context, sg Saga, t StatusTransition, events ...outbox.Message) error
+	GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
+}
+
+type SagaCoordinator struct {
+	store          SagaStore
+	stockClient    pb.StockServiceClient
+	paymentClient  pb.PaymentServiceClient
+	paymentTimeout time.Duration
+	interval       time.Duration
+	kick           chan struct{}
+}
+
+func NewSagaCoordinator(store SagaStore, stockClient pb.StockServiceClient, paymentClient pb.PaymentServiceClient, paymentTimeout time.Duration, interval time.Duration) *SagaCoordinator {
+	return &SagaCoordinator{
+		store:          store,
+		stockClient:    stockClient,
+		paymentClient:  paymentClient,
+		paymentTimeout: paymentTimeout,
+		interval:       interval,
+		kick:           make(chan struct{}, sagaKickQueueSize),
+	}
+}
+
+// NewSaga returns the initial state of a saga for a freshly created order.
+// It must be persisted together with the order.
+func (c *SagaCoordinator) NewSaga(ctx context.Context, orderID string) Saga {
+	return Saga{
+		OrderID:       orderID,
+		State:         SagaStarted,
+		Deadline:      time.Now().Add(c.paymentTimeout),
+		CorrelationID: logging.CorrelationID(ctx),
+	}
+}
+
+// Kick wakes the coordinator up without waiting for the next tick.
+func (c *SagaCoordinator) Kick() {
+	select {
+	case c.kick <- struct{}{}:
+	default:
+	}
+}
+
+// Start drives every unfinished saga forward until ctx is canceled. Since
+// all state lives in the store, sagas interrupted by a restart are picked up
+// on the first iteration.
+func (c *SagaCoordinator) Start(ctx context.Context) {
+	slog.Info("starting saga coordinator")
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		sagas, err := c.store.ListActiveSagas(ctx)
+		if err != nil {
+			slog.Error("failed to list active sagas", "error", err)
+		}
+
+		for _, sg := range sagas {
+			c.advance(logging.EnsureCorrelationID(ctx, sg.CorrelationID), sg)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-c.kick:
+		}
+	}
+}
+
+// advance runs steps of a saga until it has to wait for something external
+// or reaches a final state.
+func (c *SagaCoordinator) advance(ctx context.Context, sg Saga) {
+	for {
+		next, err := c.step(ctx, sg)
+		if err != nil {
+			slog.WarnContext(ctx, "saga step failed", "order_id", sg.OrderID, "state", sg.State, "error", err)
+			next = c.onError(sg, err)
+		}
+
+		if next == sg {
+			return
+		}
+
+		if next.State == SagaCompleted || next.State == SagaAborted {
+			return
+		}
+
+		if err := c.store.UpdateSaga(ctx, sg.State, next); err != nil {
+			slog.ErrorContext(ctx, "failed to persist saga", "order_id", sg.OrderID, "error", err)
+			return
+		}
+
+		if next.State == sg.State {
+			// Only bookkeeping changed (e.g. a retry was recorded), so wait
+			// for the next tick before trying again.
+			return
+		}
+
+		slog.InfoContext(ctx, "saga advanced", "order_id", sg.OrderID, "from", sg.State, "to", next.State)
+		sg = next
+	}
+}
+
+func (c *SagaCoordinator) step(ctx context.Context, sg Saga) (Saga, error) {
+	switch sg.State {
+	case SagaStarted:
+		return c.bookStock(ctx, sg)
+	case SagaStockBooked:
+		return c.awaitPayment(ctx, sg)
+	case SagaPaid:
+		return c.finalize(ctx, sg)
+	case SagaCompensating:
+		return c.compensate(ctx, sg)
+	default:
+		return sg, fmt.Errorf("unknown saga state %q", sg.State)
+	}
+}
+
+// onError decides whether a failed step is retried or the saga gives up and
+// starts compensating.
+func (c *SagaCoordinator) onError(sg Saga, err error) Saga {
+	sg.Attempts++
+	sg.LastError = err.Error()
+
+	if sg.State == SagaCompensating {
+		// Compensation has to succeed eventually; keep retrying.
+		return sg
+	}
+
+	if isTransient(err) && sg.Attempts < sagaMaxAttempts && time.Now().Before(sg.Deadline) {
+		return sg
+	}
+
+	sg.State = SagaCompensating
+	sg.Attempts = 0
+	return sg
+}
+
+func (c *SagaCoordinator) bookStock(ctx context.Context, sg Saga) (Saga, error) {
+	o, err := c.store.GetOrder(ctx, sg.OrderID)
+	if err != nil {
+		return sg, err
+	}
+
+	_, err = c.stockClient.BookItems(ctx, &pb.BookItemsRequest{
+		OrderID: sg.OrderID,
+		Items:   mergeItemsQuantities(mapItemToItemWithQuantity(o.Items)),
+	})
+	if err != nil {
+		return sg, fmt.Errorf("failed to book stock: %w", err)
+	}
+
+	sg.State = SagaStockBooked
+	sg.Attempts = 0
+	sg.LastError = ""
+	return sg, nil
+}
+
+func (c *SagaCoordinator) awaitPayment(ctx context.Context, sg Saga) (Saga, error) {
+	expired := !time.Now().Before(sg.Deadline)
+
+	resp, err := c.paymentClient.GetPayment(ctx, &pb.GetPaymentRequest{OrderID: sg.OrderID})
+	if err != nil {
+		if expired {
+			return c.abort(sg, "payment timed out"), nil
+		}
+		if status.Code(err) == codes.NotFound {
+			return c.createPaymentIntent(ctx, sg)
+		}
+		return sg, nil
+	}
+
+	switch resp.Payment.Status {
+	case pb.PaymentStatus_PAYMENT_CAPTURED.String():
+		o, err := c.store.GetOrder(ctx, sg.OrderID)
+		if err != nil {
+			return sg, err
+		}
+
+		sg.PaymentID = resp.Payment.ID
+		// A payment for less than the order is refunded by compensation
+		// rather than accepted.
+		if reason := paymentMismatch(o, resp.Payment); reason != "" {
+			return c.abort(sg, reason), nil
+		}
+
+		sg.State = SagaPaid
+		sg.Attempts = 0
+		sg.LastError = ""
+		return sg, nil
+	case pb.PaymentStatus_PAYMENT_FAILED.String():
+		sg.PaymentID = resp.Payment.ID
+		return c.abort(sg, "payment failed"), nil
+	}
+
+	if expired {
+		return c.abort(sg, "payment timed out"), nil
+	}
+	return sg, nil
+}
+
+// createPaymentIntent opens the payment the customer captures to pay for
+// the order.
+func (c *SagaCoordinator) createPaymentIntent(ctx context.Context, sg Saga) (Saga, error) {
+	o, err := c.store.GetOrder(ctx, sg.OrderID)
+	if err != nil {
+		return sg, err
+	}
+
+	total := money.FromProto(o.Total)
+	resp, err := c.paymentClient.CreatePaymentIntent(ctx, &pb.CreatePaymentIntentRequest{
+		OrderID:    o.ID,
+		CustomerID: o.CustomerID,
+		Amount:     total.Amount,
+		Currency:   total.Currency,
+	})
+	if err != nil {
+		// Another intent for the order was opened in the meantime; it is
+		// picked up on the next step.
+		var conflict *common.ConflictError
+		if errors.As(common.FromStatus(err), &conflict) {
+			return sg, nil
+		}
+		return sg, fmt.Errorf("failed to create payment intent: %w", err)
+	}
+
+	slog.InfoContext(ctx, "created payment intent", "order_id", sg.OrderID, "payment_id", resp.Payment.ID)
+	sg.PaymentID = resp.Payment.ID
+	return sg, nil
+}
+
+// paymentMismatch reports why a payment does not pay for an order's total,
+// or returns "" if it does.
+func paymentMismatch(o *pb.Order, p *pb.Payment) string {
+	total := money.FromProto(o.Total)
+	if p.Amount != total.Amount || !strings.EqualFold(p.Currency, total.Currency) {
+		return fmt.Sprintf("payment %s of %s does not match the order total of %s", p.ID, money.New(p.Amount, p.Currency), total)
+	}
+	return ""
+}
+
+func (c *SagaCoordinator) finalize(ctx context.Context, sg Saga) (Saga, error) {
+	_, err := c.stockClient.FinalizeBooking(ctx, &pb.FinalizeBookingRequest{OrderID: sg.OrderID})
+	if err != nil {
+		return sg, fmt.Errorf("failed to finalize booking: %w", err)
+	}
+
+	o, err := c.store.GetOrder(ctx, sg.OrderID)
+	if err != nil {
+		return sg, err
+	}
+	o.Status = pb.OrderStatus_PAID.String()
+
+	// The kitchen only learns about an order once it has been paid for.
+	payload, err := json.Marshal(o)
+	if err != nil {
+		return sg, err
+	}
+
+	sg.State = SagaCompleted
+	sg.LastError = ""
+	err = c.store.FinishSaga(ctx, sg, StatusTransition{
+		OrderID:     sg.OrderID,
+		From:        pb.OrderStatus_PENDING,
+		To:          pb.OrderStatus_PAID,
+		TriggeredBy: sagaTriggeredBy,
+		Reason:      "payment " + sg.PaymentID + " captured",
+	}, outbox.Message{
+		Topic:   TopicOrderCreated,
+		Key:     o.ID,
+		Payload: payload,
+	})
+	if err != nil {
+		return sg, err
+	}
+
+	slog.InfoContext(ctx, "saga completed", "order_id", sg.OrderID)
+	return sg, nil
+}
+
+func (c *SagaCoordinator) compensate(ctx context.Context, sg Saga) (Saga, error) {
+	o, err := c.store.GetOrder(ctx, sg.OrderID)
+	if err != nil {
+		return sg, err
+	}
+
+	// Finalizing may have gone through even though the saga gave up
+	// afterwards, e.g. when recording the paid order failed.
+	if err := restockOrder(ctx, c.stockClient, o, pb.OrderStatus_PENDING); err != nil {
+		return sg, err
+	}
+
+	// Look the payment up by order rather than sg.PaymentID so that a
+	// payment captured after the saga gave up is refunded as well.
+	if _, err := refundOrderPayment(ctx, c.paymentClient, sg.OrderID); err != nil {
+		return sg, err
+	}
+
+	reason := sg.LastError
+	sg.State = SagaAborted
+	err = c.store.FinishSaga(ctx, sg, StatusTransition{
+		OrderID:     sg.OrderID,
+		From:        pb.OrderStatus_PENDING,
+		To:          pb.OrderStatus_CANCELED,
+		TriggeredBy: sagaTriggeredBy,
+		Reason:      reason,
+	})
+	if err != nil {
+		return sg, err
+	}
+
+	slog.InfoContext(ctx, "saga aborted", "order_id", sg.OrderID, "reason", reason)
+	return sg, nil
+}
+
+// ExpireBooking sends the saga of an order whose stock booking expired
+// before it was paid for to compensation. Sagas past waiting for the
+// payment are left alone: a paid one fails to finalize and compensates on
+// its own, and a finished one has nothing left to expire.
+func (c *SagaCoordinator) ExpireBooking(ctx context.Context, orderID string) error {
+	sg, err := c.store.GetSaga(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	if sg.State != SagaStockBooked {
+		slog.InfoContext(ctx, "ignoring expired booking", "order_id", orderID, "state", sg.State)
+		return nil
+	}
+
+	if err := c.store.UpdateSaga(ctx, sg.State, c.abort(sg, "stock booking expired")); err != nil {
+		return err
+	}
+
+	slog.InfoContext(ctx, "saga advanced", "order_id", orderID, "from", sg.State, "to", SagaCompensating)
+	c.Kick()
+	return nil
+}
+
+func (c *SagaCoordinator) abort(sg Saga, reason string) Saga {
+	sg.State = SagaCompensating
+	sg.Attempts = 0
+	sg.LastError = reason
+	return sg
+}
+
+func isTransient(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	}
+	return false
+}
@@ -2,37 +2,225 @@ package main
 
 import (
 	"context"
-	"log"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
 )
 
 type OrderService interface {
-	CreateOrder(context.Context, *pb.Order) error
+	CreateOrder(context.Context, *pb.Order, *pb.CreateOrderRequest) (*pb.Order, error)
+	ReplayOrder(context.Context, *pb.CreateOrderRequest) (*pb.Order, error)
+	QuoteOrder(context.Context, *pb.Order, string) error
 	ValidateOrder(context.Context, *pb.CreateOrderRequest) error
 	GetOrder(context.Context, string) (*pb.Order, error)
-	GetUserOrders(context.Context, string) ([]*pb.Order, error)
-	PatchOrderStatus(context.Context, string, pb.OrderStatus) (*pb.Order, error)
+	GetUserOrders(context.Context, *pb.GetUserOrdersRequest) (*pb.GetUserOrdersResponse, error)
+	PatchOrderStatus(context.Context, *pb.PatchOrderStatusRequest) (*pb.Order, error)
+	AdvanceOrder(context.Context, string, pb.OrderStatus, string) (*pb.Order, error)
+	CompleteOrder(context.Context, string) (*pb.Order, error)
+	CancelOrder(context.Context, *pb.CancelOrderRequest) (*pb.Order, error)
+	FailOrder(context.Context, string, string, string) (*pb.Order, error)
+	SetEstimatedReadyAt(context.Context, string, time.Time) error
+	WatchOrder(context.Context, *pb.WatchOrderRequest, func(*pb.OrderStatusEvent) error) error
 }
 
 type service struct {
-	store       OrderStore
-	stockClient pb.StockServiceClient
+	store         OrderStore
+	stockClient   pb.StockServiceClient
+	paymentClient pb.PaymentServiceClient
+	pricingClient pb.PricingServiceClient
+	saga          *SagaCoordinator
+	feed          *StatusFeed
+	pricing       PricingConfig
+	// idempotencyTTL is how long a CreateOrder response is replayed for
+	// retries carrying the same idempotency key.
+	idempotencyTTL time.Duration
 }
 
-func NewOrderService(store OrderStore, stockClient pb.StockServiceClient) *service {
-	return &service{store: store, stockClient: stockClient}
+func NewOrderService(store OrderStore, stockClient pb.StockServiceClient, paymentClient pb.PaymentServiceClient, pricingClient pb.PricingServiceClient, saga *SagaCoordinator, feed *StatusFeed, pricing PricingConfig, idempotencyTTL time.Duration) *service {
+	return &service{
+		store:          store,
+		stockClient:    stockClient,
+		paymentClient:  paymentClient,
+		pricingClient:  pricingClient,
+		saga:           saga,
+		feed:           feed,
+		pricing:        pricing,
+		idempotencyTTL: idempotencyTTL,
+	}
+}
+
+// CreateOrder persists the order together with its saga. Booking stock,
+// collecting payment and notifying the kitchen happen asynchronously in the
+// SagaCoordinator.
+//
+// If req carries an idempotency key, the resulting order is stored under it
+// and a concurrent request that claimed the key first wins: its order is
+// returned instead of o.
+func (s *service) CreateOrder(ctx context.Context, o *pb.Order, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	slog.InfoContext(ctx, "creating order", "order_id", o.ID, "customer_id", o.CustomerID)
+	if err := s.QuoteOrder(ctx, o, req.PromoCode); err != nil {
+		return nil, err
+	}
+
+	var idem *IdempotencyRecord
+	if req.IdempotencyKey != "" {
+		hash, err := requestHash(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash request: %w", err)
+		}
+		idem = &IdempotencyRecord{
+			CustomerID:  o.CustomerID,
+			Key:         req.IdempotencyKey,
+			RequestHash: hash,
+			Order:       o,
+			ExpiresAt:   time.Now().Add(s.idempotencyTTL),
+		}
+	}
+
+	err := s.store.Create(ctx, o, s.saga.NewSaga(ctx, o.ID), idem)
+	if errors.Is(err, errIdempotencyKeyTaken) {
+		replayed, err := s.ReplayOrder(ctx, req)
+		if err != nil {
+			return nil, err
+		}
+		if replayed == nil {
+			return nil, common.Conflict("idempotency key", req.IdempotencyKey, "idempotency key is in use, retry the request")
+		}
+		return replayed, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.saga.Kick()
+	return o, nil
 }
 
-func (s *service) CreateOrder(ctx context.Context, o *pb.Order) error {
-	log.Printf("Creating order: %v", o)
-	merged := mergeItemsQuantities(mapItemToItemWithQuantity(o.Items))
-	s.stockClient.BookItems(ctx, &pb.BookItemsRequest{
-		Items:   merged,
-		OrderID: o.ID,
+// ReplayOrder returns the order previously created for req's idempotency
+// key, or nil if there is none, in which case the request should proceed.
+// Reusing a key for a different request is a conflict.
+func (s *service) ReplayOrder(ctx context.Context, req *pb.CreateOrderRequest) (*pb.Order, error) {
+	if req.IdempotencyKey == "" {
+		return nil, nil
+	}
+	if len(req.IdempotencyKey) > maxIdempotencyKeyLength {
+		return nil, common.InvalidArgument("idempotency key", fmt.Sprintf("cannot be longer than %d characters", maxIdempotencyKeyLength))
+	}
+
+	rec, err := s.store.GetIdempotencyRecord(ctx, req.CustomerID, req.IdempotencyKey)
+	var notFound *common.NotFoundError
+	if errors.As(err, &notFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	hash, err := requestHash(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash request: %w", err)
+	}
+	if hash != rec.RequestHash {
+		return nil, common.Conflict("idempotency key", req.IdempotencyKey, "idempotency key was already used for a different request")
+	}
+
+	slog.InfoContext(ctx, "replaying order for idempotency key", "order_id", rec.Order.ID, "customer_id", req.CustomerID)
+	return rec.Order, nil
+}
+
+// QuoteOrder prices o as of now: it snapshots the catalog details of every
+// item, applies the price lists in effect and fills in the totals. Nothing
+// is persisted.
+func (s *service) QuoteOrder(ctx context.Context, o *pb.Order, promoCode string) error {
+	if err := s.enrichItems(ctx, o.Items); err != nil {
+		return err
+	}
+	if err := s.resolvePrices(ctx, o.Items); err != nil {
+		return err
+	}
+
+	var discountRate int64
+	if promoCode != "" {
+		rate, ok := s.pricing.PromoCodes[strings.ToUpper(promoCode)]
+		if !ok {
+			return common.InvalidArgument("promo code", fmt.Sprintf("%q is unknown", promoCode))
+		}
+		discountRate = rate
+	}
+
+	totals, err := computeTotals(o.Items, discountRate, s.pricing.TaxRate)
+	if err != nil {
+		return err
+	}
+	totals.apply(o)
+	return nil
+}
+
+// resolvePrices replaces the catalog price of every item with the one the
+// pricing service currently quotes for its PriceID, if any. Only price lists
+// in the currency of the first item are considered, so that the order stays
+// in a single currency.
+func (s *service) resolvePrices(ctx context.Context, items []*pb.Item) error {
+	var priceIDs []string
+	for _, item := range items {
+		if item.PriceID != "" {
+			priceIDs = append(priceIDs, item.PriceID)
+		}
+	}
+	if len(priceIDs) == 0 {
+		return nil
+	}
+
+	resp, err := s.pricingClient.ResolvePrices(ctx, &pb.ResolvePricesRequest{
+		PriceIDs: priceIDs,
+		Currency: items[0].GetUnitPrice().GetCurrency(),
 	})
-	return s.store.Create(ctx, o)
+	if err != nil {
+		return fmt.Errorf("failed to resolve prices: %w", err)
+	}
+
+	prices := make(map[string]*pb.Money, len(resp.Prices))
+	for _, p := range resp.Prices {
+		prices[p.PriceID] = p.Price
+	}
+	for _, item := range items {
+		if price, ok := prices[item.PriceID]; ok {
+			item.UnitPrice = price
+		}
+	}
+
+	return nil
+}
+
+// enrichItems copies the catalog details of every item onto the order, so
+// that later catalog changes do not alter what the customer bought.
+func (s *service) enrichItems(ctx context.Context, items []*pb.Item) error {
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		ids = append(ids, item.ID)
+	}
+
+	resp, err := s.stockClient.GetStockItems(ctx, &pb.GetStockItemsRequest{IDs: ids})
+	if err != nil {
+		return fmt.Errorf("failed to look up order items: %w", err)
+	}
+
+	for i, stockItem := range resp.Items {
+		if stockItem.Price == nil {
+			return common.Conflict("stock item", stockItem.ID, fmt.Sprintf("stock item %s has no price", stockItem.ID))
+		}
+		items[i].Name = stockItem.Name
+		items[i].PriceID = stockItem.PriceID
+		items[i].UnitPrice = stockItem.Price
+		items[i].Station = stockItem.Station
+	}
+
+	return nil
 }
 
 func (s *service) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) error {
@@ -41,23 +229,31 @@ func (s *service) ValidateOrder(ctx context.Context, p *pb.CreateOrderRequest) e
 	}
 
 	merged := mergeItemsQuantities(p.Items)
-	log.Printf("Merged items: %v", merged)
+	slog.DebugContext(ctx, "merged items", "items", merged)
 
 	resp, err := s.stockClient.VerifyStock(ctx, &pb.VerifyStockRequest{
 		Items: merged,
 	})
 
 	if err != nil {
-		log.Printf("Error verifying stock: %v", err)
+		slog.ErrorContext(ctx, "failed to verify stock", "error", err)
 		return err
 	}
 
 	if !resp.AllAvailable {
-		log.Printf("Error verifying stock: %v", err)
-		return common.ErrNoStock
+		slog.InfoContext(ctx, "insufficient stock", "missing", resp.MissingOrInsufficient)
+		shortages := make([]common.StockShortage, 0, len(resp.Shortages))
+		for _, s := range resp.Shortages {
+			shortages = append(shortages, common.StockShortage{
+				ItemID:    s.ItemID,
+				Requested: s.Requested,
+				Available: s.Available,
+			})
+		}
+		return common.InsufficientStock(shortages...)
 	}
 
-	log.Printf("Validated order: %v", resp.AllAvailable)
+	slog.DebugContext(ctx, "validated order")
 
 	return nil
 }
@@ -66,12 +262,302 @@ func (s *service) GetOrder(ctx context.Context, id string) (*pb.Order, error) {
 	return s.store.GetOrder(ctx, id)
 }
 
-func (s *service) GetUserOrders(ctx context.Context, customerID string) ([]*pb.Order, error) {
-	return s.store.GetUserOrders(ctx, customerID)
+func (s *service) GetUserOrders(ctx context.Context, req *pb.GetUserOrdersRequest) (*pb.GetUserOrdersResponse, error) {
+	if req.CustomerID == "" {
+		return nil, common.InvalidArgument("customer ID", "is required")
+	}
+
+	pageSize, err := common.PageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	q := OrderQuery{
+		CustomerID: req.CustomerID,
+		PageSize:   pageSize,
+	}
+
+	for _, st := range req.Statuses {
+		if _, ok := orderTransitions[st]; !ok {
+			return nil, common.InvalidArgument("statuses", fmt.Sprintf("contain unknown status %s", st))
+		}
+		q.Statuses = append(q.Statuses, st)
+	}
+
+	if req.CreatedAfter != nil {
+		q.CreatedAfter = req.CreatedAfter.AsTime()
+	}
+	if req.CreatedBefore != nil {
+		q.CreatedBefore = req.CreatedBefore.AsTime()
+	}
+	if !q.CreatedAfter.IsZero() && !q.CreatedBefore.IsZero() && !q.CreatedAfter.Before(q.CreatedBefore) {
+		return nil, common.InvalidArgument("created after", "must be before created before")
+	}
+
+	if req.PageToken != "" {
+		var c orderCursor
+		if err := common.DecodePageToken(req.PageToken, &c); err != nil {
+			return nil, err
+		}
+		q.After = &c
+	}
+
+	orders, next, err := s.store.GetUserOrders(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.GetUserOrdersResponse{Orders: orders}
+	if next != nil {
+		resp.NextPageToken, err = common.EncodePageToken(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *service) PatchOrderStatus(ctx context.Context, req *pb.PatchOrderStatusRequest) (*pb.Order, error) {
+	o, err := s.store.GetOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := parseOrderStatus(o.Status)
+	if err := validateTransition(req.OrderID, from, req.Status); err != nil {
+		return nil, err
+	}
+
+	triggeredBy := req.TriggeredBy
+	if triggeredBy == "" {
+		triggeredBy = "api"
+	}
+
+	slog.InfoContext(ctx, "changing order status", "order_id", req.OrderID, "from", from, "to", req.Status, "triggered_by", triggeredBy)
+
+	return s.store.PatchOrderStatus(ctx, StatusTransition{
+		OrderID:     req.OrderID,
+		From:        from,
+		To:          req.Status,
+		TriggeredBy: triggeredBy,
+		Reason:      req.Reason,
+	})
+}
+
+// AdvanceOrder moves a paid order forward along the kitchen path up to
+// target, recording every intermediate step. Orders that are already at or
+// past target are returned unchanged, which makes it safe to call again for
+// redelivered events.
+func (s *service) AdvanceOrder(ctx context.Context, orderID string, target pb.OrderStatus, triggeredBy string) (*pb.Order, error) {
+	o, err := s.store.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	steps, err := stepsTo(orderID, parseOrderStatus(o.Status), target)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, st := range steps {
+		o, err = s.PatchOrderStatus(ctx, &pb.PatchOrderStatusRequest{
+			OrderID:     orderID,
+			Status:      st,
+			TriggeredBy: triggeredBy,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return o, nil
 }
 
-func (s *service) PatchOrderStatus(ctx context.Context, orderID string, status pb.OrderStatus) (*pb.Order, error) {
-	return s.store.PatchOrderStatus(ctx, orderID, status)
+// CompleteOrder marks an order finished by the kitchen as COMPLETED and makes
+// sure its stock booking is finalized.
+func (s *service) CompleteOrder(ctx context.Context, orderID string) (*pb.Order, error) {
+	o, err := s.AdvanceOrder(ctx, orderID, pb.OrderStatus_COMPLETED, "kitchen")
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.stockClient.FinalizeBooking(ctx, &pb.FinalizeBookingRequest{OrderID: orderID})
+	if err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "completed order", "order_id", orderID)
+	return o, nil
+}
+
+// CancelOrder cancels an order that has not been paid for and handed to the
+// kitchen yet, gives back its stock and refunds a payment captured in the
+// meantime. Canceling an already canceled order retries the restock and
+// refund, so clients can safely call it again after a partial failure.
+func (s *service) CancelOrder(ctx context.Context, req *pb.CancelOrderRequest) (*pb.Order, error) {
+	o, err := s.store.GetOrder(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := parseOrderStatus(o.Status)
+	if from == pb.OrderStatus_CANCELED {
+		// Retrying the cancellation restocks the order as it was when it
+		// was canceled.
+		from, err = s.statusBefore(ctx, req.OrderID, pb.OrderStatus_CANCELED)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		if err := validateTransition(req.OrderID, from, pb.OrderStatus_CANCELED); err != nil {
+			return nil, err
+		}
+
+		reason := req.Reason
+		if reason == "" {
+			reason = "canceled by customer"
+		}
+
+		o, err = s.store.CancelOrder(ctx, StatusTransition{
+			OrderID:     req.OrderID,
+			From:        from,
+			To:          pb.OrderStatus_CANCELED,
+			TriggeredBy: "customer:" + o.CustomerID,
+			Reason:      reason,
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		slog.InfoContext(ctx, "canceled order", "order_id", req.OrderID, "reason", reason)
+	}
+
+	if err := restockOrder(ctx, s.stockClient, o, from); err != nil {
+		return nil, err
+	}
+
+	refunded, err := refundOrderPayment(ctx, s.paymentClient, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	if !refunded {
+		return o, nil
+	}
+
+	return s.store.PatchOrderStatus(ctx, StatusTransition{
+		OrderID:     req.OrderID,
+		From:        pb.OrderStatus_CANCELED,
+		To:          pb.OrderStatus_REFUNDED,
+		TriggeredBy: "payment",
+		Reason:      "refunded after cancellation",
+	})
+}
+
+// statusBefore returns the status an order had when it last moved to status.
+func (s *service) statusBefore(ctx context.Context, orderID string, status pb.OrderStatus) (pb.OrderStatus, error) {
+	events, err := s.store.GetStatusHistory(ctx, orderID, 0)
+	if err != nil {
+		return 0, err
+	}
+	for i := len(events) - 1; i >= 0; i-- {
+		if events[i].To == status {
+			return events[i].From, nil
+		}
+	}
+	return 0, common.NotFound("status change", fmt.Sprintf("%s to %s", orderID, status))
+}
+
+// WatchOrder passes the status changes of an order to send until the order
+// reaches a final status, send fails or ctx is canceled.
+func (s *service) WatchOrder(ctx context.Context, req *pb.WatchOrderRequest, send func(*pb.OrderStatusEvent) error) error {
+	if req.AfterSequence < 0 {
+		return common.InvalidArgument("after sequence", "cannot be negative")
+	}
+
+	// Subscribe before reading anything, so that a change made in between
+	// still wakes us up.
+	updates, unsubscribe := s.feed.Subscribe(req.OrderID)
+	defer unsubscribe()
+
+	o, err := s.store.GetOrder(ctx, req.OrderID)
+	if err != nil {
+		return err
+	}
+
+	current := parseOrderStatus(o.Status)
+	lastSeq := req.AfterSequence
+	for {
+		events, err := s.store.GetStatusHistory(ctx, req.OrderID, lastSeq)
+		if err != nil {
+			return err
+		}
+		for _, e := range events {
+			if err := send(e); err != nil {
+				return err
+			}
+			lastSeq = e.Sequence
+			current = e.To
+		}
+
+		if isFinal(current) {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-updates:
+		}
+	}
+}
+
+// FailOrder refunds an order the kitchen could not prepare: its stock is
+// returned, its payment refunded and it ends up REFUNDED.
+// Failing an order that already was refunded is a no-op.
+func (s *service) FailOrder(ctx context.Context, orderID string, reason string, triggeredBy string) (*pb.Order, error) {
+	o, err := s.store.GetOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	from := parseOrderStatus(o.Status)
+	if from == pb.OrderStatus_REFUNDED {
+		return o, nil
+	}
+	if err := validateTransition(orderID, from, pb.OrderStatus_REFUNDED); err != nil {
+		return nil, err
+	}
+
+	if err := restockOrder(ctx, s.stockClient, o, from); err != nil {
+		return nil, err
+	}
+	if _, err := refundOrderPayment(ctx, s.paymentClient, orderID); err != nil {
+		return nil, err
+	}
+
+	if reason == "" {
+		reason = "kitchen failed to prepare the order"
+	}
+	slog.WarnContext(ctx, "refunding order failed by the kitchen", "order_id", orderID, "reason", reason)
+
+	return s.store.PatchOrderStatus(ctx, StatusTransition{
+		OrderID:     orderID,
+		From:        from,
+		To:          pb.OrderStatus_REFUNDED,
+		TriggeredBy: triggeredBy,
+		Reason:      reason,
+	})
+}
+
+// SetEstimatedReadyAt records the kitchen's estimate of when an order will
+// be ready.
+func (s *service) SetEstimatedReadyAt(ctx context.Context, orderID string, readyAt time.Time) error {
+	if err := s.store.SetEstimatedReadyAt(ctx, orderID, readyAt); err != nil {
+		return err
+	}
+	slog.InfoContext(ctx, "estimated order", "order_id", orderID, "ready_at", readyAt)
+	return nil
 }
 
 func mergeItemsQuantities(items []*pb.ItemWithQuantity) []*pb.ItemWithQuantity {
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+// orderTransitions lists, for every status, the statuses an order may move
+// to next. Anything not listed here is rejected.
+//
+// Only pending orders can be canceled. A paid order has been handed to the
+// kitchen, which may start cooking it before the order service hears about
+// it, so from then on only the kitchen can give up on it (REFUNDED).
+var orderTransitions = map[pb.OrderStatus][]pb.OrderStatus{
+	pb.OrderStatus_PENDING:   {pb.OrderStatus_PAID, pb.OrderStatus_CANCELED},
+	pb.OrderStatus_PAID:      {pb.OrderStatus_ACCEPTED, pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_ACCEPTED:  {pb.OrderStatus_PREPARING, pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_PREPARING: {pb.OrderStatus_READY, pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_READY:     {pb.OrderStatus_COMPLETED, pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_COMPLETED: {pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_CANCELED:  {pb.OrderStatus_REFUNDED},
+	pb.OrderStatus_REFUNDED:  {},
+}
+
+// StatusTransition is a single recorded change of an order's status.
+type StatusTransition struct {
+	OrderID     string
+	From        pb.OrderStatus
+	To          pb.OrderStatus
+	TriggeredBy string
+	Reason      string
+}
+
+func parseOrderStatus(s string) pb.OrderStatus {
+	return pb.OrderStatus(pb.OrderStatus_value[s])
+}
+
+func canTransition(from, to pb.OrderStatus) bool {
+	for _, next := range orderTransitions[from] {
+		if next == to {
+			return true
+		}
+	}
+	return false
+}
+
+func validateTransition(orderID string, from, to pb.OrderStatus) error {
+	if !canTransition(from, to) {
+		return common.Conflict("order", orderID, fmt.Sprintf("order %s cannot move from %s to %s", orderID, from, to))
+	}
+	return nil
+}
+
+// kitchenPath lists, in order, the statuses the kitchen moves a paid order
+// through.
+var kitchenPath = []pb.OrderStatus{
+	pb.OrderStatus_PAID,
+	pb.OrderStatus_ACCEPTED,
+	pb.OrderStatus_PREPARING,
+	pb.OrderStatus_READY,
+	pb.OrderStatus_COMPLETED,
+}
+
+// stepsTo returns the statuses an order in from has to go through to reach
+// to along kitchenPath, excluding from itself. It is empty if the order
+// already is at or past to, and an error if from is not on the path at all.
+func stepsTo(orderID string, from, to pb.OrderStatus) ([]pb.OrderStatus, error) {
+	fromIdx, toIdx := -1, -1
+	for i, st := range kitchenPath {
+		if st == from {
+			fromIdx = i
+		}
+		if st == to {
+			toIdx = i
+		}
+	}
+
+	if fromIdx == -1 || toIdx == -1 {
+		return nil, common.Conflict("order", orderID, fmt.Sprintf("order %s cannot move from %s to %s", orderID, from, to))
+	}
+	if fromIdx >= toIdx {
+		return nil, nil
+	}
+
+	return kitchenPath[fromIdx+1 : toIdx+1], nil
+}
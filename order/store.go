@@ -3,19 +3,53 @@ package main
 import (
 	"context"
 	"database/sql"
+	"embed"
+	"encoding/json"
 	"fmt"
-	"log"
 	"strings"
+	"time"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/migrate"
+	"github.com/kiriyms/oms_go-common/outbox"
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// OrderQuery selects a page of a customer's orders.
+type OrderQuery struct {
+	CustomerID    string
+	Statuses      []pb.OrderStatus
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+	PageSize      int
+	// After is the last order of the previous page, if any.
+	After *orderCursor
+}
+
+// orderCursor identifies the position of an order in a listing. CreatedAt
+// is kept in the format SQLite stores timestamps in so it compares exactly.
+type orderCursor struct {
+	CreatedAt string `json:"c"`
+	ID        string `json:"i"`
+}
+
 type OrderStore interface {
-	Create(context.Context, *pb.Order) error
+	outbox.Store
+	SagaStore
+	IdempotencyStore
+	StatusHistoryStore
+	// Create persists the order and its saga, and the idempotency record if
+	// one is given. It fails with errIdempotencyKeyTaken if the record's key
+	// is already in use.
+	Create(context.Context, *pb.Order, Saga, *IdempotencyRecord) error
 	GetOrder(context.Context, string) (*pb.Order, error)
-	GetUserOrders(context.Context, string) ([]*pb.Order, error)
-	PatchOrderStatus(context.Context, string, pb.OrderStatus) (*pb.Order, error)
+	GetUserOrders(context.Context, OrderQuery) ([]*pb.Order, *orderCursor, error)
+	PatchOrderStatus(context.Context, StatusTransition) (*pb.Order, error)
+	CancelOrder(context.Context, StatusTransition) (*pb.Order, error)
+	SetEstimatedReadyAt(ctx context.Context, orderID string, readyAt time.Time) error
 	Close() error
 }
 
@@ -23,43 +57,58 @@ type store struct {
 	db *sql.DB
 }
 
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
 func NewStore(dbPath string) (*store, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := migrate.Open("sqlite3", dbPath, migrationsFS, "migrations")
 	if err != nil {
 		return nil, err
 	}
+	return &store{db: db}, nil
+}
 
-	if err := db.Ping(); err != nil {
-		return nil, err
-	}
-
-	s := &store{db: db}
-	return s, nil
+// RunMigrationCommand prints the pending migrations or rolls back the given
+// number of them without starting the service.
+func RunMigrationCommand(dbPath string, printPending bool, down int) error {
+	return migrate.RunCommand("sqlite3", dbPath, migrationsFS, "migrations", printPending, down)
 }
 
-func (s *store) Create(ctx context.Context, o *pb.Order) error {
-	log.Printf("Creating order in the database: %+v", o)
+func (s *store) Create(ctx context.Context, o *pb.Order, sg Saga, idem *IdempotencyRecord) error {
 	tx, err := s.db.Begin()
 	if err != nil {
 		return err
 	}
 	defer tx.Rollback()
 
+	if idem != nil {
+		if err := insertIdempotencyRecord(ctx, tx, idem); err != nil {
+			return err
+		}
+	}
+
 	_, err = tx.Exec(`
-		INSERT INTO orders (id, customer_id, status)
-		VALUES (?, ?, ?)
+		INSERT INTO orders (id, customer_id, status, currency, subtotal, discount, tax, total, vip, rush)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`,
 		o.ID,
 		o.CustomerID,
 		o.Status,
+		o.GetTotal().GetCurrency(),
+		o.GetSubtotal().GetAmount(),
+		o.GetDiscount().GetAmount(),
+		o.GetTax().GetAmount(),
+		o.GetTotal().GetAmount(),
+		o.VIP,
+		o.Rush,
 	)
 	if err != nil {
 		return fmt.Errorf("failed to insert order: %w", err)
 	}
 
 	stmt, err := tx.Prepare(`
-		INSERT INTO order_items (order_id, item_id, quantity)
-		VALUES (?, ?, ?)
+		INSERT INTO order_items (order_id, item_id, quantity, name, price_id, unit_price, station)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
 	`)
 	if err != nil {
 		return fmt.Errorf("failed to prepare order_items stmt: %w", err)
@@ -71,12 +120,31 @@ func (s *store) Create(ctx context.Context, o *pb.Order) error {
 			return fmt.Errorf("invalid quantity %d for item %s", item.Quantity, item.ID)
 		}
 
-		_, err := stmt.Exec(o.ID, item.ID, item.Quantity)
+		_, err := stmt.Exec(o.ID, item.ID, item.Quantity, item.Name, item.PriceID, item.GetUnitPrice().GetAmount(), item.Station)
 		if err != nil {
 			return fmt.Errorf("failed to insert order item %s: %w", item.ID, err)
 		}
 	}
 
+	err = insertStatusHistory(ctx, tx, StatusTransition{
+		OrderID:     o.ID,
+		From:        pb.OrderStatus_UNKNOWN,
+		To:          parseOrderStatus(o.Status),
+		TriggeredBy: "customer:" + o.CustomerID,
+		Reason:      "order created",
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO sagas (order_id, state, payment_id, attempts, last_error, deadline, correlation_id, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`, sg.OrderID, sg.State, sg.PaymentID, sg.Attempts, sg.LastError, sg.Deadline, sg.CorrelationID)
+	if err != nil {
+		return fmt.Errorf("failed to insert saga: %w", err)
+	}
+
 	if err := tx.Commit(); err != nil {
 		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
@@ -91,28 +159,21 @@ func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
 	}
 	defer tx.Rollback()
 
-	var o pb.Order
-
-	err = tx.QueryRowContext(ctx, `
-		SELECT id, customer_id, status
-		FROM orders
+	o, err := scanOrder(tx.QueryRowContext(ctx, selectOrdersSQL+`
 		WHERE id = ?
-	`, orderID).Scan(
-		&o.ID,
-		&o.CustomerID,
-		&o.Status,
-	)
+	`, orderID))
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("order %s not found", orderID)
+			return nil, common.NotFound("order", orderID)
 		}
 		return nil, fmt.Errorf("failed to fetch order: %w", err)
 	}
 
 	rows, err := tx.QueryContext(ctx, `
-		SELECT item_id, quantity
+		SELECT item_id, quantity, name, price_id, unit_price, station
 		FROM order_items
 		WHERE order_id = ?
+		ORDER BY id
 	`, orderID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch order items: %w", err)
@@ -120,10 +181,14 @@ func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
 	defer rows.Close()
 
 	for rows.Next() {
-		var item pb.Item
-		if err := rows.Scan(&item.ID, &item.Quantity); err != nil {
+		var (
+			item      pb.Item
+			unitPrice int64
+		)
+		if err := rows.Scan(&item.ID, &item.Quantity, &item.Name, &item.PriceID, &unitPrice, &item.Station); err != nil {
 			return nil, fmt.Errorf("failed to scan order item: %w", err)
 		}
+		item.UnitPrice = orderMoney(o, unitPrice)
 		o.Items = append(o.Items, &item)
 	}
 
@@ -135,98 +200,466 @@ func (s *store) GetOrder(ctx context.Context, orderID string) (*pb.Order, error)
 		return nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	return &o, nil
+	return o, nil
+}
+
+// GetIdempotencyRecord returns the unexpired record of a customer's
+// idempotency key, with the order created for it.
+func (s *store) GetIdempotencyRecord(ctx context.Context, customerID, key string) (*IdempotencyRecord, error) {
+	var (
+		rec      = IdempotencyRecord{CustomerID: customerID, Key: key}
+		response []byte
+	)
+
+	err := s.db.QueryRowContext(ctx, `
+		SELECT request_hash, response, expires_at
+		FROM idempotency_keys
+		WHERE customer_id = ?
+		  AND key = ?
+		  AND expires_at > ?
+	`, customerID, key, sqliteTime(time.Now())).Scan(&rec.RequestHash, &response, &rec.ExpiresAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("idempotency key", key)
+		}
+		return nil, fmt.Errorf("failed to fetch idempotency key: %w", err)
+	}
+
+	rec.Order = &pb.Order{}
+	if err := proto.Unmarshal(response, rec.Order); err != nil {
+		return nil, fmt.Errorf("failed to decode stored response: %w", err)
+	}
+
+	return &rec, nil
+}
+
+// insertIdempotencyRecord claims the record's key. Expired records, this
+// key's included, are purged on the way so the table does not grow forever.
+func insertIdempotencyRecord(ctx context.Context, tx *sql.Tx, rec *IdempotencyRecord) error {
+	response, err := proto.Marshal(rec.Order)
+	if err != nil {
+		return fmt.Errorf("failed to encode response: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM idempotency_keys
+		WHERE expires_at <= ?
+	`, sqliteTime(time.Now()))
+	if err != nil {
+		return fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+
+	res, err := tx.ExecContext(ctx, `
+		INSERT INTO idempotency_keys (customer_id, key, request_hash, order_id, response, expires_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+		ON CONFLICT(customer_id, key) DO NOTHING
+	`, rec.CustomerID, rec.Key, rec.RequestHash, rec.Order.ID, response, sqliteTime(rec.ExpiresAt))
+	if err != nil {
+		return fmt.Errorf("failed to insert idempotency key: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return errIdempotencyKeyTaken
+	}
+	return nil
+}
+
+func (s *store) GetStatusHistory(ctx context.Context, orderID string, afterSeq int64) ([]*pb.OrderStatusEvent, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT id, order_id, from_status, to_status, triggered_by, reason, created_at
+		FROM order_status_history
+		WHERE order_id = ?
+		  AND id > ?
+		ORDER BY id
+	`, orderID, afterSeq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch status history: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*pb.OrderStatusEvent
+	for rows.Next() {
+		var (
+			e        pb.OrderStatusEvent
+			from, to string
+			at       time.Time
+		)
+		if err := rows.Scan(&e.Sequence, &e.OrderID, &from, &to, &e.TriggeredBy, &e.Reason, &at); err != nil {
+			return nil, fmt.Errorf("failed to scan status change: %w", err)
+		}
+		e.From = parseOrderStatus(from)
+		e.To = parseOrderStatus(to)
+		e.At = timestamppb.New(at)
+		events = append(events, &e)
+	}
+
+	return events, rows.Err()
 }
 
-func (s *store) GetUserOrders(ctx context.Context, userID string) ([]*pb.Order, error) {
+func (s *store) ChangedOrders(ctx context.Context, afterSeq int64) ([]string, int64, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT order_id, MAX(id)
+		FROM order_status_history
+		WHERE id > ?
+		GROUP BY order_id
+	`, afterSeq)
+	if err != nil {
+		return nil, afterSeq, fmt.Errorf("failed to fetch changed orders: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		orderIDs []string
+		lastSeq  = afterSeq
+	)
+	for rows.Next() {
+		var (
+			id  string
+			seq int64
+		)
+		if err := rows.Scan(&id, &seq); err != nil {
+			return nil, afterSeq, fmt.Errorf("failed to scan changed order: %w", err)
+		}
+		orderIDs = append(orderIDs, id)
+		lastSeq = max(lastSeq, seq)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, afterSeq, err
+	}
+
+	return orderIDs, lastSeq, nil
+}
+
+func (s *store) LatestStatusSequence(ctx context.Context) (int64, error) {
+	var seq int64
+	err := s.db.QueryRowContext(ctx, `
+		SELECT COALESCE(MAX(id), 0)
+		FROM order_status_history
+	`).Scan(&seq)
+	if err != nil {
+		return 0, fmt.Errorf("failed to fetch latest status sequence: %w", err)
+	}
+	return seq, nil
+}
+
+// GetUserOrders returns a page of a customer's orders, newest first with the
+// ID as tie-breaker, and the cursor of the next page if there is one.
+func (s *store) GetUserOrders(ctx context.Context, q OrderQuery) ([]*pb.Order, *orderCursor, error) {
+	conds := []string{"customer_id = ?"}
+	args := []any{q.CustomerID}
+
+	if len(q.Statuses) > 0 {
+		statuses := make([]string, 0, len(q.Statuses))
+		for _, st := range q.Statuses {
+			statuses = append(statuses, st.String())
+		}
+		cond, statusArgs := buildInQuery("status IN (%s)", statuses)
+		conds = append(conds, cond)
+		args = append(args, statusArgs...)
+	}
+	if !q.CreatedAfter.IsZero() {
+		conds = append(conds, "created_at >= ?")
+		args = append(args, sqliteTime(q.CreatedAfter))
+	}
+	if !q.CreatedBefore.IsZero() {
+		conds = append(conds, "created_at < ?")
+		args = append(args, sqliteTime(q.CreatedBefore))
+	}
+	if c := q.After; c != nil {
+		conds = append(conds, "(created_at < ? OR (created_at = ? AND id < ?))")
+		args = append(args, c.CreatedAt, c.CreatedAt, c.ID)
+	}
+
+	// One extra row tells whether there is a next page.
+	args = append(args, q.PageSize+1)
+
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer tx.Rollback()
 
-	rows, err := tx.QueryContext(ctx, `
-		SELECT id, customer_id, status
-		FROM orders
-		WHERE customer_id = ?
-		ORDER BY created_at DESC
-	`, userID)
+	rows, err := tx.QueryContext(ctx, selectOrdersSQL+`
+		WHERE `+strings.Join(conds, " AND ")+`
+		ORDER BY created_at DESC, id DESC
+		LIMIT ?
+	`, args...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch user orders: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch user orders: %w", err)
 	}
 	defer rows.Close()
 
-	orderMap := make(map[string]*pb.Order)
-	var orderIDs []string
-
+	orders := make([]*pb.Order, 0, q.PageSize+1)
+	byID := make(map[string]*pb.Order)
 	for rows.Next() {
-		var o pb.Order
-		if err := rows.Scan(&o.ID, &o.CustomerID, &o.Status); err != nil {
-			return nil, fmt.Errorf("failed to scan order: %w", err)
+		o, err := scanOrder(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan order: %w", err)
 		}
-		orderMap[o.ID] = &o
-		orderIDs = append(orderIDs, o.ID)
+		orders = append(orders, o)
+		byID[o.ID] = o
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	var next *orderCursor
+	if len(orders) > q.PageSize {
+		delete(byID, orders[q.PageSize].ID)
+		orders = orders[:q.PageSize]
+		last := orders[len(orders)-1]
+		next = &orderCursor{CreatedAt: sqliteTime(last.CreatedAt.AsTime()), ID: last.ID}
 	}
 
-	if len(orderIDs) == 0 {
-		return []*pb.Order{}, nil
+	if len(orders) == 0 {
+		return orders, nil, nil
 	}
 
-	query, args := buildInQuery(`
-		SELECT order_id, item_id, quantity
+	orderIDs := make([]string, 0, len(orders))
+	for _, o := range orders {
+		orderIDs = append(orderIDs, o.ID)
+	}
+
+	query, itemArgs := buildInQuery(`
+		SELECT order_id, item_id, quantity, name, price_id, unit_price, station
 		FROM order_items
 		WHERE order_id IN (%s)
+		ORDER BY id
 	`, orderIDs)
 
-	itemRows, err := tx.QueryContext(ctx, query, args...)
+	itemRows, err := tx.QueryContext(ctx, query, itemArgs...)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch order items: %w", err)
+		return nil, nil, fmt.Errorf("failed to fetch order items: %w", err)
 	}
 	defer itemRows.Close()
 
 	for itemRows.Next() {
-		var orderID string
-		var item pb.Item
-
-		if err := itemRows.Scan(&orderID, &item.ID, &item.Quantity); err != nil {
-			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		var (
+			orderID   string
+			item      pb.Item
+			unitPrice int64
+		)
+
+		if err := itemRows.Scan(&orderID, &item.ID, &item.Quantity, &item.Name, &item.PriceID, &unitPrice, &item.Station); err != nil {
+			return nil, nil, fmt.Errorf("failed to scan order item: %w", err)
 		}
 
-		orderMap[orderID].Items = append(orderMap[orderID].Items, &item)
+		o := byID[orderID]
+		item.UnitPrice = orderMoney(o, unitPrice)
+		o.Items = append(o.Items, &item)
 	}
 
 	if err := itemRows.Err(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+		return nil, nil, fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	orders := make([]*pb.Order, 0, len(orderMap))
-	for _, o := range orderMap {
-		orders = append(orders, o)
+	return orders, next, nil
+}
+
+func (s *store) PatchOrderStatus(ctx context.Context, t StatusTransition) (*pb.Order, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
 	}
+	defer tx.Rollback()
 
-	return orders, nil
+	if err := updateOrderStatus(ctx, tx, t); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetOrder(ctx, t.OrderID)
 }
 
-func (s *store) PatchOrderStatus(ctx context.Context, orderID string, status pb.OrderStatus) (*pb.Order, error) {
-	_, err := s.db.ExecContext(ctx, `
+func (s *store) SetEstimatedReadyAt(ctx context.Context, orderID string, readyAt time.Time) error {
+	res, err := s.db.ExecContext(ctx, `
 		UPDATE orders
-		SET status = ?
+		SET estimated_ready_at = ?
 		WHERE id = ?
-	`, status.String(), orderID)
+	`, sqliteTime(readyAt), orderID)
+	if err != nil {
+		return fmt.Errorf("failed to set estimated ready time: %w", err)
+	}
 
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return common.NotFound("order", orderID)
+	}
+	return nil
+}
+
+// CancelOrder moves the order to CANCELED, aborts its saga if one is still
+// running and enqueues an orders.canceled event, all in one transaction.
+func (s *store) CancelOrder(ctx context.Context, t StatusTransition) (*pb.Order, error) {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := updateOrderStatus(ctx, tx, t); err != nil {
+		return nil, err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE sagas
+		SET state = ?,
+		    last_error = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = ?
+		  AND state NOT IN (?, ?)
+	`, SagaAborted, "canceled: "+t.Reason, t.OrderID, SagaCompleted, SagaAborted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to abort saga %s: %w", t.OrderID, err)
+	}
+
+	payload, err := json.Marshal(&pb.CancelOrderRequest{OrderID: t.OrderID, Reason: t.Reason})
+	if err != nil {
+		return nil, err
+	}
+
+	err = outbox.Insert(ctx, tx, outbox.Message{
+		Topic:   TopicOrderCanceled,
+		Key:     t.OrderID,
+		Payload: payload,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return s.GetOrder(ctx, orderID)
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetOrder(ctx, t.OrderID)
+}
+
+func (s *store) ListActiveSagas(ctx context.Context) ([]Saga, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT order_id, state, payment_id, attempts, last_error, deadline, correlation_id
+		FROM sagas
+		WHERE state NOT IN (?, ?)
+		ORDER BY created_at ASC
+	`, SagaCompleted, SagaAborted)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sagas: %w", err)
+	}
+	defer rows.Close()
+
+	var sagas []Saga
+	for rows.Next() {
+		var sg Saga
+		if err := rows.Scan(&sg.OrderID, &sg.State, &sg.PaymentID, &sg.Attempts, &sg.LastError, &sg.Deadline, &sg.CorrelationID); err != nil {
+			return nil, fmt.Errorf("failed to scan saga: %w", err)
+		}
+		sagas = append(sagas, sg)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return sagas, nil
+}
+
+func (s *store) GetSaga(ctx context.Context, orderID string) (Saga, error) {
+	var sg Saga
+	err := s.db.QueryRowContext(ctx, `
+		SELECT order_id, state, payment_id, attempts, last_error, deadline, correlation_id
+		FROM sagas
+		WHERE order_id = ?
+	`, orderID).Scan(&sg.OrderID, &sg.State, &sg.PaymentID, &sg.Attempts, &sg.LastError, &sg.Deadline, &sg.CorrelationID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Saga{}, common.NotFound("saga", orderID)
+		}
+		return Saga{}, fmt.Errorf("failed to fetch saga: %w", err)
+	}
+	return sg, nil
+}
+
+func (s *store) UpdateSaga(ctx context.Context, from string, sg Saga) error {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE sagas
+		SET state = ?,
+		    payment_id = ?,
+		    attempts = ?,
+		    last_error = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = ?
+		  AND state = ?
+	`, sg.State, sg.PaymentID, sg.Attempts, sg.LastError, sg.OrderID, from)
+	if err != nil {
+		return fmt.Errorf("failed to update saga %s: %w", sg.OrderID, err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return fmt.Errorf("saga %s is no longer %s", sg.OrderID, from)
+	}
+	return nil
+}
+
+func (s *store) FinishSaga(ctx context.Context, sg Saga, t StatusTransition, events ...outbox.Message) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := updateOrderStatus(ctx, tx, t); err != nil {
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE sagas
+		SET state = ?,
+		    payment_id = ?,
+		    attempts = ?,
+		    last_error = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE order_id = ?
+	`, sg.State, sg.PaymentID, sg.Attempts, sg.LastError, sg.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to update saga %s: %w", sg.OrderID, err)
+	}
+
+	for _, e := range events {
+		if err := outbox.Insert(ctx, tx, e); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (s *store) FetchOutbox(ctx context.Context, limit int) ([]outbox.Message, error) {
+	return outbox.Fetch(ctx, s.db, limit)
+}
+
+func (s *store) MarkOutboxPublished(ctx context.Context, id int64) error {
+	return outbox.MarkPublished(ctx, s.db, id)
+}
+
+func (s *store) MarkOutboxFailed(ctx context.Context, id int64, cause error) error {
+	return outbox.MarkFailed(ctx, s.db, id, cause)
 }
 
 func (s *store) Close() error {
@@ -234,6 +667,112 @@ func (s *store) Close() error {
 	return err
 }
 
+func updateOrderStatus(ctx context.Context, tx *sql.Tx, t StatusTransition) error {
+	res, err := tx.ExecContext(ctx, `
+		UPDATE orders
+		SET status = ?
+		WHERE id = ?
+		  AND status = ?
+	`, t.To.String(), t.OrderID, t.From.String())
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return common.Conflict("order", t.OrderID, fmt.Sprintf("order %s is no longer %s", t.OrderID, t.From))
+	}
+
+	return insertStatusHistory(ctx, tx, t)
+}
+
+func insertStatusHistory(ctx context.Context, tx *sql.Tx, t StatusTransition) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO order_status_history (order_id, from_status, to_status, triggered_by, reason, created_at)
+		VALUES (?, ?, ?, ?, ?, CURRENT_TIMESTAMP)
+	`,
+		t.OrderID,
+		t.From.String(),
+		t.To.String(),
+		t.TriggeredBy,
+		t.Reason,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record status change: %w", err)
+	}
+	return nil
+}
+
+const selectOrdersSQL = `
+	SELECT id, customer_id, status, created_at, currency, subtotal, discount, tax, total, estimated_ready_at, vip, rush
+	FROM orders
+`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanOrder(row rowScanner) (*pb.Order, error) {
+	var (
+		o         pb.Order
+		createdAt time.Time
+		currency  string
+		subtotal  int64
+		discount  int64
+		tax       int64
+		total     int64
+		readyAt   sql.NullTime
+	)
+
+	err := row.Scan(
+		&o.ID,
+		&o.CustomerID,
+		&o.Status,
+		&createdAt,
+		&currency,
+		&subtotal,
+		&discount,
+		&tax,
+		&total,
+		&readyAt,
+		&o.VIP,
+		&o.Rush,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o.CreatedAt = timestamppb.New(createdAt)
+	if readyAt.Valid {
+		o.EstimatedReadyAt = timestamppb.New(readyAt.Time)
+	}
+	// Orders placed before prices existed have no currency and no totals.
+	if currency != "" {
+		o.Subtotal = &pb.Money{Currency: currency, Amount: subtotal}
+		o.Discount = &pb.Money{Currency: currency, Amount: discount}
+		o.Tax = &pb.Money{Currency: currency, Amount: tax}
+		o.Total = &pb.Money{Currency: currency, Amount: total}
+	}
+	return &o, nil
+}
+
+// orderMoney returns amount in the currency of o, or nil if o is unpriced.
+func orderMoney(o *pb.Order, amount int64) *pb.Money {
+	if o.Total == nil {
+		return nil
+	}
+	return &pb.Money{Currency: o.Total.Currency, Amount: amount}
+}
+
+// sqliteTime formats t the way CURRENT_TIMESTAMP does, so that it can be
+// compared against created_at columns.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
+
 func buildInQuery(base string, ids []string) (string, []any) {
 	placeholders := make([]string, len(ids))
 	args := make([]any, len(ids))
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/money"
+)
+
+// basisPoints is the denominator of rates given in hundredths of a percent.
+const basisPoints = 10000
+
+// PricingConfig holds the rates applied on top of item prices.
+type PricingConfig struct {
+	// TaxRate is in basis points, e.g. 825 for 8.25%.
+	TaxRate int64
+	// PromoCodes maps a promo code to its discount in basis points.
+	PromoCodes map[string]int64
+}
+
+// OrderTotals is the price breakdown of an order.
+type OrderTotals struct {
+	Subtotal money.Money
+	Discount money.Money
+	Tax      money.Money
+	Total    money.Money
+}
+
+// computeTotals prices items, which must all carry a unit price in the same
+// currency. The discount is rounded down and the tax, charged on the
+// discounted subtotal, half up.
+func computeTotals(items []*pb.Item, discountRate, taxRate int64) (OrderTotals, error) {
+	if len(items) == 0 {
+		return OrderTotals{}, common.ErrNoItems
+	}
+
+	currency := items[0].GetUnitPrice().GetCurrency()
+	subtotal := money.Zero(currency)
+	for _, item := range items {
+		line, err := money.FromProto(item.UnitPrice).Mul(int64(item.Quantity))
+		if err != nil {
+			return OrderTotals{}, fmt.Errorf("failed to price item %s: %w", item.ID, err)
+		}
+		subtotal, err = subtotal.Add(line)
+		if errors.Is(err, money.ErrCurrencyMismatch) {
+			return OrderTotals{}, common.Conflict("order", "", fmt.Sprintf("item %s is not priced in %s", item.ID, currency))
+		}
+		if err != nil {
+			return OrderTotals{}, err
+		}
+	}
+
+	discount, err := subtotal.Scale(discountRate, basisPoints, money.Down)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	taxable, err := subtotal.Sub(discount)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	tax, err := taxable.Scale(taxRate, basisPoints, money.HalfUp)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	total, err := taxable.Add(tax)
+	if err != nil {
+		return OrderTotals{}, err
+	}
+
+	return OrderTotals{
+		Subtotal: subtotal,
+		Discount: discount,
+		Tax:      tax,
+		Total:    total,
+	}, nil
+}
+
+func (t OrderTotals) apply(o *pb.Order) {
+	o.Subtotal = t.Subtotal.Proto()
+	o.Discount = t.Discount.Proto()
+	o.Tax = t.Tax.Proto()
+	o.Total = t.Total.Proto()
+}
+
+// parseRate converts a percentage such as "8.25" into basis points.
+func parseRate(s string) (int64, error) {
+	pct, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(s), "%"), 64)
+	if err != nil || pct < 0 || pct > 100 {
+		return 0, fmt.Errorf("invalid rate %q, want a percentage between 0 and 100", s)
+	}
+	return int64(math.Round(pct * 100)), nil
+}
+
+// parsePromoCodes reads a comma-separated list of CODE=percent pairs, e.g.
+// "WELCOME10=10,STAFF=25".
+func parsePromoCodes(s string) (map[string]int64, error) {
+	codes := make(map[string]int64)
+	for _, pair := range strings.Split(s, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+
+		code, rate, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid promo code %q, want CODE=percent", pair)
+		}
+
+		bps, err := parseRate(rate)
+		if err != nil {
+			return nil, fmt.Errorf("invalid promo code %q: %w", pair, err)
+		}
+		codes[strings.ToUpper(strings.TrimSpace(code))] = bps
+	}
+	return codes, nil
+}
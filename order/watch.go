@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+type StatusHistoryStore interface {
+	// GetStatusHistory returns the status changes of an order recorded after
+	// the given sequence, oldest first.
+	GetStatusHistory(ctx context.Context, orderID string, afterSeq int64) ([]*pb.OrderStatusEvent, error)
+	// ChangedOrders returns the orders whose status changed after the given
+	// sequence, and the latest sequence seen.
+	ChangedOrders(ctx context.Context, afterSeq int64) ([]string, int64, error)
+	LatestStatusSequence(ctx context.Context) (int64, error)
+}
+
+// StatusFeed tails the status history and wakes up the watchers of orders
+// that changed. It follows the table rather than the code paths that write
+// it, so no change is missed, whether made by the API, the saga or events
+// from the kitchen.
+type StatusFeed struct {
+	store    StatusHistoryStore
+	interval time.Duration
+
+	mu       sync.Mutex
+	watchers map[string]map[chan struct{}]struct{}
+}
+
+func NewStatusFeed(store StatusHistoryStore, interval time.Duration) *StatusFeed {
+	return &StatusFeed{
+		store:    store,
+		interval: interval,
+		watchers: make(map[string]map[chan struct{}]struct{}),
+	}
+}
+
+// Subscribe returns a channel that receives a value whenever orderID
+// changes. Notifications are coalesced, so watchers must re-read the
+// history rather than count them. The returned function unsubscribes.
+func (f *StatusFeed) Subscribe(orderID string) (<-chan struct{}, func()) {
+	ch := make(chan struct{}, 1)
+
+	f.mu.Lock()
+	if f.watchers[orderID] == nil {
+		f.watchers[orderID] = make(map[chan struct{}]struct{})
+	}
+	f.watchers[orderID][ch] = struct{}{}
+	f.mu.Unlock()
+
+	return ch, func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.watchers[orderID], ch)
+		if len(f.watchers[orderID]) == 0 {
+			delete(f.watchers, orderID)
+		}
+	}
+}
+
+func (f *StatusFeed) Start(ctx context.Context) {
+	slog.Info("starting status feed")
+
+	lastSeq, err := f.store.LatestStatusSequence(ctx)
+	if err != nil {
+		slog.Error("failed to read latest status sequence", "error", err)
+	}
+
+	ticker := time.NewTicker(f.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		orderIDs, seq, err := f.store.ChangedOrders(ctx, lastSeq)
+		if err != nil {
+			slog.Error("failed to poll status changes", "error", err)
+			continue
+		}
+		lastSeq = seq
+
+		f.notify(orderIDs)
+	}
+}
+
+func (f *StatusFeed) notify(orderIDs []string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for _, id := range orderIDs {
+		for ch := range f.watchers[id] {
+			select {
+			case ch <- struct{}{}:
+			default:
+			}
+		}
+	}
+}
+
+// isFinal reports whether an order in st can no longer change status.
+func isFinal(st pb.OrderStatus) bool {
+	next, ok := orderTransitions[st]
+	return ok && len(next) == 0
+}
@@ -1,3 +1,8 @@
 module github.com/kiriyms/oms_go-payment
 
 go 1.25.1
+
+require (
+	github.com/google/uuid v1.6.0 // indirect
+	github.com/mattn/go-sqlite3 v1.14.33 // indirect
+)
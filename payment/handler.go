@@ -0,0 +1,61 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/grpc"
+)
+
+type Handler struct {
+	pb.UnimplementedPaymentServiceServer
+	service PaymentService
+}
+
+func NewHandler(s *grpc.Server, service PaymentService) *Handler {
+	h := &Handler{
+		service: service,
+	}
+	pb.RegisterPaymentServiceServer(s, h)
+	return h
+}
+
+func (h *Handler) CreatePaymentIntent(ctx context.Context, req *pb.CreatePaymentIntentRequest) (*pb.CreatePaymentIntentResponse, error) {
+	p, err := h.service.CreatePaymentIntent(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CreatePaymentIntentResponse{Payment: p}, nil
+}
+
+func (h *Handler) CapturePayment(ctx context.Context, req *pb.CapturePaymentRequest) (*pb.CapturePaymentResponse, error) {
+	p, err := h.service.CapturePayment(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.CapturePaymentResponse{Payment: p}, nil
+}
+
+func (h *Handler) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.RefundPaymentResponse, error) {
+	p, err := h.service.RefundPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.RefundPaymentResponse{Payment: p}, nil
+}
+
+func (h *Handler) VoidPayment(ctx context.Context, req *pb.VoidPaymentRequest) (*pb.VoidPaymentResponse, error) {
+	p, err := h.service.VoidPayment(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.VoidPaymentResponse{Payment: p}, nil
+}
+
+func (h *Handler) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.GetPaymentResponse, error) {
+	p, err := h.service.GetPayment(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetPaymentResponse{Payment: p}, nil
+}
@@ -0,0 +1,65 @@
+package main
+
+import (
+	"flag"
+	"log/slog"
+	"net"
+
+	common "github.com/kiriyms/oms_go-common"
+	"github.com/kiriyms/oms_go-common/logging"
+	"google.golang.org/grpc"
+)
+
+var (
+	grpcAddr     = common.GetEnv("GRPC_ADDR", "localhost:50053")
+	dbPath       = common.GetEnv("DB_PATH", "./db/db.db")
+	providerName = common.GetEnv("PAYMENT_PROVIDER", "fake")
+)
+
+var (
+	printPendingMigrations = flag.Bool("pending-migrations", false, "print pending database migrations and exit")
+	migrateDown            = flag.Int("migrate-down", 0, "roll back the given number of database migrations and exit")
+)
+
+func main() {
+	logging.Init("payment")
+
+	flag.Parse()
+	if *printPendingMigrations || *migrateDown > 0 {
+		if err := RunMigrationCommand(dbPath, *printPendingMigrations, *migrateDown); err != nil {
+			logging.Fatal("migration command failed", "error", err)
+		}
+		return
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), common.UnaryErrorInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), common.StreamErrorInterceptor()),
+	)
+	l, err := net.Listen("tcp", grpcAddr)
+	if err != nil {
+		logging.Fatal("failed to listen", "error", err)
+	}
+	defer l.Close()
+
+	store, err := NewStore(dbPath)
+	if err != nil {
+		logging.Fatal("failed to create store", "error", err)
+	}
+	defer store.Close()
+
+	provider, err := NewProvider(providerName)
+	if err != nil {
+		logging.Fatal("failed to create payment provider", "error", err)
+	}
+	slog.Info("using payment provider", "provider", provider.Name())
+
+	service := NewPaymentService(store, provider)
+	NewHandler(grpcServer, service)
+
+	slog.Info("grpc server listening", "addr", grpcAddr)
+
+	if err := grpcServer.Serve(l); err != nil {
+		logging.Fatal("grpc server stopped", "error", err)
+	}
+}
@@ -0,0 +1,15 @@
+CREATE TABLE payments (
+    id              TEXT PRIMARY KEY,
+    order_id        TEXT NOT NULL,
+    customer_id     TEXT NOT NULL,
+    amount          INTEGER NOT NULL CHECK (amount > 0),
+    refunded_amount INTEGER NOT NULL DEFAULT 0,
+    currency        TEXT NOT NULL,
+    status          TEXT NOT NULL,
+    provider        TEXT NOT NULL,
+    provider_ref    TEXT NOT NULL,
+    created_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at      DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_payments_order_id ON payments (order_id, created_at);
@@ -0,0 +1,4 @@
+-- An order has at most one payment that is pending or captured, so that
+-- the payment found by order is the one that pays for it.
+CREATE UNIQUE INDEX idx_payments_open_order ON payments (order_id)
+    WHERE status IN ('PAYMENT_PENDING', 'PAYMENT_CAPTURED');
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Provider is the external payment processor the service delegates money
+// movement to. Amounts are always in minor units of the given currency.
+type Provider interface {
+	Name() string
+	CreateIntent(ctx context.Context, amount int64, currency string) (string, error)
+	Capture(ctx context.Context, ref string) error
+	Refund(ctx context.Context, ref string, amount int64) error
+	// Cancel gives up on an intent that was not captured.
+	Cancel(ctx context.Context, ref string) error
+}
+
+func NewProvider(name string) (Provider, error) {
+	switch name {
+	case "fake":
+		return &fakeProvider{}, nil
+	default:
+		return nil, fmt.Errorf("unknown payment provider %q", name)
+	}
+}
+
+const fakeRefPrefix = "fake_pi_"
+
+// fakeProvider approves every well-formed request without talking to anyone,
+// so the whole order flow can run offline. Payment state lives in the store,
+// which is why the provider itself keeps nothing in memory.
+type fakeProvider struct{}
+
+func (p *fakeProvider) Name() string {
+	return "fake"
+}
+
+func (p *fakeProvider) CreateIntent(ctx context.Context, amount int64, currency string) (string, error) {
+	if amount <= 0 {
+		return "", fmt.Errorf("amount must be positive")
+	}
+	if currency == "" {
+		return "", fmt.Errorf("currency is required")
+	}
+	return fakeRefPrefix + uuid.New().String(), nil
+}
+
+func (p *fakeProvider) Capture(ctx context.Context, ref string) error {
+	if !strings.HasPrefix(ref, fakeRefPrefix) {
+		return fmt.Errorf("unknown payment intent %s", ref)
+	}
+	return nil
+}
+
+func (p *fakeProvider) Refund(ctx context.Context, ref string, amount int64) error {
+	if !strings.HasPrefix(ref, fakeRefPrefix) {
+		return fmt.Errorf("unknown payment intent %s", ref)
+	}
+	if amount <= 0 {
+		return fmt.Errorf("refund amount must be positive")
+	}
+	return nil
+}
+
+func (p *fakeProvider) Cancel(ctx context.Context, ref string) error {
+	if !strings.HasPrefix(ref, fakeRefPrefix) {
+		return fmt.Errorf("unknown payment intent %s", ref)
+	}
+	return nil
+}
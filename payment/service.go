@@ -0,0 +1,202 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/google/uuid"
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+type PaymentService interface {
+	CreatePaymentIntent(ctx context.Context, req *pb.CreatePaymentIntentRequest) (*pb.Payment, error)
+	CapturePayment(ctx context.Context, paymentID string) (*pb.Payment, error)
+	RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.Payment, error)
+	VoidPayment(ctx context.Context, paymentID string) (*pb.Payment, error)
+	GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.Payment, error)
+}
+
+type service struct {
+	store    PaymentStore
+	provider Provider
+}
+
+func NewPaymentService(store PaymentStore, provider Provider) *service {
+	return &service{store: store, provider: provider}
+}
+
+func (s *service) CreatePaymentIntent(ctx context.Context, req *pb.CreatePaymentIntentRequest) (*pb.Payment, error) {
+	if req.OrderID == "" {
+		return nil, common.InvalidArgument("order ID", "is required")
+	}
+	if req.Amount <= 0 {
+		return nil, common.InvalidArgument("amount", "must be positive")
+	}
+	if req.Currency == "" {
+		return nil, common.InvalidArgument("currency", "is required")
+	}
+
+	// Checked up front so that the provider is not asked for an intent
+	// that is thrown away; the store enforces it either way.
+	existing, err := s.store.GetPaymentByOrder(ctx, req.OrderID)
+	var notFound *common.NotFoundError
+	switch {
+	case errors.As(err, &notFound):
+	case err != nil:
+		return nil, err
+	case existing.Status == pb.PaymentStatus_PAYMENT_PENDING.String() || existing.Status == pb.PaymentStatus_PAYMENT_CAPTURED.String():
+		return nil, common.Conflict("payment for order", req.OrderID, fmt.Sprintf("order %s already has payment %s in status %s", req.OrderID, existing.ID, existing.Status))
+	}
+
+	ref, err := s.provider.CreateIntent(ctx, req.Amount, req.Currency)
+	if err != nil {
+		return nil, fmt.Errorf("provider %s rejected intent: %w", s.provider.Name(), err)
+	}
+
+	p := &pb.Payment{
+		ID:          uuid.New().String(),
+		OrderID:     req.OrderID,
+		CustomerID:  req.CustomerID,
+		Amount:      req.Amount,
+		Currency:    req.Currency,
+		Status:      pb.PaymentStatus_PAYMENT_PENDING.String(),
+		Provider:    s.provider.Name(),
+		ProviderRef: ref,
+	}
+
+	created, err := s.store.CreatePayment(ctx, p)
+	if err != nil {
+		if cErr := s.provider.Cancel(ctx, ref); cErr != nil {
+			slog.ErrorContext(ctx, "failed to cancel unused intent", "order_id", p.OrderID, "error", cErr)
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "created payment intent", "payment_id", p.ID, "order_id", p.OrderID, "amount", p.Amount, "currency", p.Currency)
+	return created, nil
+}
+
+func (s *service) CapturePayment(ctx context.Context, paymentID string) (*pb.Payment, error) {
+	p, err := s.store.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != pb.PaymentStatus_PAYMENT_PENDING.String() {
+		return nil, common.Conflict("payment", paymentID, fmt.Sprintf("payment %s cannot be captured in status %s", paymentID, p.Status))
+	}
+
+	if err := s.provider.Capture(ctx, p.ProviderRef); err != nil {
+		slog.WarnContext(ctx, "capture failed", "payment_id", paymentID, "error", err)
+		if _, uErr := s.store.UpdatePayment(ctx, p, pb.PaymentStatus_PAYMENT_FAILED, p.RefundedAmount); uErr != nil {
+			slog.ErrorContext(ctx, "failed to mark payment as failed", "payment_id", paymentID, "error", uErr)
+		}
+		return nil, fmt.Errorf("provider %s failed to capture: %w", p.Provider, err)
+	}
+
+	updated, err := s.store.UpdatePayment(ctx, p, pb.PaymentStatus_PAYMENT_CAPTURED, p.RefundedAmount)
+	if err != nil {
+		// If the payment was voided while it was being captured, e.g.
+		// because its order was canceled, the money goes straight back.
+		if current, gErr := s.store.GetPayment(ctx, paymentID); gErr == nil && current.Status == pb.PaymentStatus_PAYMENT_FAILED.String() {
+			if rErr := s.provider.Refund(ctx, p.ProviderRef, p.Amount); rErr != nil {
+				slog.ErrorContext(ctx, "failed to refund capture of voided payment", "payment_id", paymentID, "error", rErr)
+			}
+		}
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "captured payment", "payment_id", paymentID)
+	return updated, nil
+}
+
+func (s *service) RefundPayment(ctx context.Context, req *pb.RefundPaymentRequest) (*pb.Payment, error) {
+	p, err := s.store.GetPayment(ctx, req.PaymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if p.Status != pb.PaymentStatus_PAYMENT_CAPTURED.String() {
+		return nil, common.Conflict("payment", req.PaymentID, fmt.Sprintf("payment %s cannot be refunded in status %s", req.PaymentID, p.Status))
+	}
+
+	remaining := p.Amount - p.RefundedAmount
+	amount := req.Amount
+	if amount == 0 {
+		amount = remaining
+	}
+	if amount < 0 || amount > remaining {
+		return nil, common.InvalidArgument("amount", fmt.Sprintf("must be between 1 and %d, got %d", remaining, amount))
+	}
+
+	refunded := p.RefundedAmount + amount
+	status := pb.PaymentStatus_PAYMENT_CAPTURED
+	if refunded == p.Amount {
+		status = pb.PaymentStatus_PAYMENT_REFUNDED
+	}
+
+	// The refund is recorded before the provider is asked for it, so that
+	// of two concurrent refunds, e.g. a cancellation racing the order saga's
+	// compensation, only one gets through.
+	updated, err := s.store.UpdatePayment(ctx, p, status, refunded)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.provider.Refund(ctx, p.ProviderRef, amount); err != nil {
+		if _, uErr := s.store.UpdatePayment(ctx, updated, pb.PaymentStatus_PAYMENT_CAPTURED, p.RefundedAmount); uErr != nil {
+			slog.ErrorContext(ctx, "failed to undo refund", "payment_id", req.PaymentID, "error", uErr)
+		}
+		return nil, fmt.Errorf("provider %s failed to refund: %w", p.Provider, err)
+	}
+
+	slog.InfoContext(ctx, "refunded payment", "payment_id", req.PaymentID, "amount", amount)
+	return updated, nil
+}
+
+// VoidPayment fails a pending payment so that it can no longer be captured.
+// Voiding an already failed payment is a no-op.
+func (s *service) VoidPayment(ctx context.Context, paymentID string) (*pb.Payment, error) {
+	p, err := s.store.GetPayment(ctx, paymentID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch p.Status {
+	case pb.PaymentStatus_PAYMENT_FAILED.String():
+		return p, nil
+	case pb.PaymentStatus_PAYMENT_PENDING.String():
+	default:
+		return nil, common.Conflict("payment", paymentID, fmt.Sprintf("payment %s cannot be voided in status %s", paymentID, p.Status))
+	}
+
+	// Like a refund, the void is recorded first so that a concurrent
+	// capture of the payment fails.
+	updated, err := s.store.UpdatePayment(ctx, p, pb.PaymentStatus_PAYMENT_FAILED, p.RefundedAmount)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.provider.Cancel(ctx, p.ProviderRef); err != nil {
+		if _, uErr := s.store.UpdatePayment(ctx, updated, pb.PaymentStatus_PAYMENT_PENDING, p.RefundedAmount); uErr != nil {
+			slog.ErrorContext(ctx, "failed to undo void", "payment_id", paymentID, "error", uErr)
+		}
+		return nil, fmt.Errorf("provider %s failed to cancel: %w", p.Provider, err)
+	}
+
+	slog.InfoContext(ctx, "voided payment", "payment_id", paymentID)
+	return updated, nil
+}
+
+func (s *service) GetPayment(ctx context.Context, req *pb.GetPaymentRequest) (*pb.Payment, error) {
+	if req.ID != "" {
+		return s.store.GetPayment(ctx, req.ID)
+	}
+	if req.OrderID != "" {
+		return s.store.GetPaymentByOrder(ctx, req.OrderID)
+	}
+	return nil, common.InvalidArgument("ID", "or order ID is required")
+}
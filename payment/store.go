@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"embed"
+	"errors"
+	"fmt"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/migrate"
+	"github.com/mattn/go-sqlite3"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PaymentStore interface {
+	// CreatePayment stores a new payment. It fails with a conflict if the
+	// order already has a pending or captured payment.
+	CreatePayment(ctx context.Context, p *pb.Payment) (*pb.Payment, error)
+	GetPayment(ctx context.Context, paymentID string) (*pb.Payment, error)
+	// GetPaymentByOrder returns the order's pending or captured payment, or
+	// its latest one if it has neither.
+	GetPaymentByOrder(ctx context.Context, orderID string) (*pb.Payment, error)
+	// UpdatePayment sets the status and refunded amount of a payment that
+	// is still as current describes, and fails with a conflict if it was
+	// changed in the meantime.
+	UpdatePayment(ctx context.Context, current *pb.Payment, status pb.PaymentStatus, refundedAmount int64) (*pb.Payment, error)
+	Close() error
+}
+
+type store struct {
+	db *sql.DB
+}
+
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
+func NewStore(dbPath string) (*store, error) {
+	db, err := migrate.Open("sqlite3", dbPath, migrationsFS, "migrations")
+	if err != nil {
+		return nil, err
+	}
+	return &store{db: db}, nil
+}
+
+// RunMigrationCommand prints the pending migrations or rolls back the given
+// number of them without starting the service.
+func RunMigrationCommand(dbPath string, printPending bool, down int) error {
+	return migrate.RunCommand("sqlite3", dbPath, migrationsFS, "migrations", printPending, down)
+}
+
+func (s *store) CreatePayment(ctx context.Context, p *pb.Payment) (*pb.Payment, error) {
+	_, err := s.db.ExecContext(ctx, `
+		INSERT INTO payments (id, order_id, customer_id, amount, refunded_amount, currency, status, provider, provider_ref, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+	`,
+		p.ID,
+		p.OrderID,
+		p.CustomerID,
+		p.Amount,
+		p.RefundedAmount,
+		p.Currency,
+		p.Status,
+		p.Provider,
+		p.ProviderRef,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.Code == sqlite3.ErrConstraint {
+			return nil, common.Conflict("payment for order", p.OrderID, fmt.Sprintf("order %s already has a payment", p.OrderID))
+		}
+		return nil, fmt.Errorf("failed to insert payment: %w", err)
+	}
+
+	return s.GetPayment(ctx, p.ID)
+}
+
+func (s *store) GetPayment(ctx context.Context, paymentID string) (*pb.Payment, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, order_id, customer_id, amount, refunded_amount, currency, status, provider, provider_ref, created_at, updated_at
+		FROM payments
+		WHERE id = ?
+	`, paymentID)
+
+	p, err := scanPayment(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("payment", paymentID)
+		}
+		return nil, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+
+	return p, nil
+}
+
+func (s *store) GetPaymentByOrder(ctx context.Context, orderID string) (*pb.Payment, error) {
+	row := s.db.QueryRowContext(ctx, `
+		SELECT id, order_id, customer_id, amount, refunded_amount, currency, status, provider, provider_ref, created_at, updated_at
+		FROM payments
+		WHERE order_id = ?
+		ORDER BY status IN (?, ?) DESC, created_at DESC, rowid DESC
+		LIMIT 1
+	`, orderID, pb.PaymentStatus_PAYMENT_PENDING.String(), pb.PaymentStatus_PAYMENT_CAPTURED.String())
+
+	p, err := scanPayment(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("payment for order", orderID)
+		}
+		return nil, fmt.Errorf("failed to fetch payment: %w", err)
+	}
+
+	return p, nil
+}
+
+func (s *store) UpdatePayment(ctx context.Context, current *pb.Payment, paymentStatus pb.PaymentStatus, refundedAmount int64) (*pb.Payment, error) {
+	res, err := s.db.ExecContext(ctx, `
+		UPDATE payments
+		SET status = ?,
+		    refunded_amount = ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+		  AND status = ?
+		  AND refunded_amount = ?
+	`, paymentStatus.String(), refundedAmount, current.ID, current.Status, current.RefundedAmount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update payment: %w", err)
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		return nil, common.Conflict("payment", current.ID, fmt.Sprintf("payment %s was changed concurrently", current.ID))
+	}
+
+	return s.GetPayment(ctx, current.ID)
+}
+
+func (s *store) Close() error {
+	return s.db.Close()
+}
+
+func scanPayment(row *sql.Row) (*pb.Payment, error) {
+	var (
+		p         pb.Payment
+		createdAt time.Time
+		updatedAt time.Time
+	)
+
+	err := row.Scan(
+		&p.ID,
+		&p.OrderID,
+		&p.CustomerID,
+		&p.Amount,
+		&p.RefundedAmount,
+		&p.Currency,
+		&p.Status,
+		&p.Provider,
+		&p.ProviderRef,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	p.CreatedAt = timestamppb.New(createdAt)
+	p.UpdatedAt = timestamppb.New(updatedAt)
+	return &p, nil
+}
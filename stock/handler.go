@@ -64,6 +64,26 @@ func (h *Handler) GetStockItem(ctx context.Context, req *pb.GetStockItemRequest)
 	return &pb.GetStockItemResponse{Item: item}, nil
 }
 
+func (h *Handler) GetStockItems(ctx context.Context, req *pb.GetStockItemsRequest) (*pb.GetStockItemsResponse, error) {
+	items, err := h.service.GetStockItems(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.GetStockItemsResponse{Items: items}, nil
+}
+
+func (h *Handler) ListStockItems(ctx context.Context, req *pb.ListStockItemsRequest) (*pb.ListStockItemsResponse, error) {
+	return h.service.ListStockItems(ctx, req)
+}
+
+func (h *Handler) AdjustStockQuantity(ctx context.Context, req *pb.AdjustStockQuantityRequest) (*pb.AdjustStockQuantityResponse, error) {
+	item, err := h.service.AdjustStockQuantity(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.AdjustStockQuantityResponse{Item: item}, nil
+}
+
 func (h *Handler) FinalizeBooking(ctx context.Context, req *pb.FinalizeBookingRequest) (*pb.FinalizeBookingResponse, error) {
 	err := h.service.FinalizeBooking(ctx, req.OrderID)
 	if err != nil {
@@ -71,3 +91,11 @@ func (h *Handler) FinalizeBooking(ctx context.Context, req *pb.FinalizeBookingRe
 	}
 	return &pb.FinalizeBookingResponse{Success: true}, nil
 }
+
+func (h *Handler) ReturnFinalizedItems(ctx context.Context, req *pb.ReturnFinalizedItemsRequest) (*pb.ReturnFinalizedItemsResponse, error) {
+	items, err := h.service.ReturnFinalizedItems(ctx, req.OrderID)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ReturnFinalizedItemsResponse{Items: items}, nil
+}
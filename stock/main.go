@@ -1,38 +1,89 @@
 package main
 
 import (
-	"log"
+	"context"
+	"flag"
+	"log/slog"
 	"net"
+	"time"
 
 	common "github.com/kiriyms/oms_go-common"
+	"github.com/kiriyms/oms_go-common/logging"
 	"google.golang.org/grpc"
 )
 
 var (
-	grpcAddr = common.GetEnv("GRPC_ADDR", "localhost:50052")
-	dbPath   = common.GetEnv("DB_PATH", "./db/db.db")
+	grpcAddr      = common.GetEnv("GRPC_ADDR", "localhost:50052")
+	dbPath        = common.GetEnv("DB_PATH", "./db/db.db")
+	brokerURL     = common.GetEnv("KAFKA_BROKER_URL", "localhost:9092")
+	bookingTTL    = common.GetEnv("BOOKING_TTL", "15m")
+	sweepInterval = common.GetEnv("BOOKING_SWEEP_INTERVAL", "1m")
+	pricingTZ     = common.GetEnv("PRICING_TIMEZONE", "UTC")
+)
+
+var (
+	printPendingMigrations = flag.Bool("pending-migrations", false, "print pending database migrations and exit")
+	migrateDown            = flag.Int("migrate-down", 0, "roll back the given number of database migrations and exit")
 )
 
 func main() {
-	grpcServer := grpc.NewServer()
+	logging.Init("stock")
+
+	flag.Parse()
+	if *printPendingMigrations || *migrateDown > 0 {
+		if err := RunMigrationCommand(dbPath, *printPendingMigrations, *migrateDown); err != nil {
+			logging.Fatal("migration command failed", "error", err)
+		}
+		return
+	}
+
+	grpcServer := grpc.NewServer(
+		grpc.ChainUnaryInterceptor(logging.UnaryServerInterceptor(), common.UnaryErrorInterceptor()),
+		grpc.ChainStreamInterceptor(logging.StreamServerInterceptor(), common.StreamErrorInterceptor()),
+	)
 	l, err := net.Listen("tcp", grpcAddr)
 	if err != nil {
-		log.Fatalf("Failed to listen: %v", err)
+		logging.Fatal("failed to listen", "error", err)
 	}
 	defer l.Close()
 
 	store, err := NewStore(dbPath)
 	if err != nil {
-		log.Fatalf("Failed to create store: %v", err)
+		logging.Fatal("failed to create store", "error", err)
 	}
 	defer store.Close()
 
-	service := NewStockService(store)
+	ttl, err := time.ParseDuration(bookingTTL)
+	if err != nil {
+		logging.Fatal("invalid BOOKING_TTL", "error", err)
+	}
+
+	interval, err := time.ParseDuration(sweepInterval)
+	if err != nil {
+		logging.Fatal("invalid BOOKING_SWEEP_INTERVAL", "error", err)
+	}
+
+	loc, err := time.LoadLocation(pricingTZ)
+	if err != nil {
+		logging.Fatal("invalid PRICING_TIMEZONE", "error", err)
+	}
+
+	producer := NewProducer(brokerURL)
+	defer producer.Close()
+
+	service := NewStockService(store, producer, ttl)
 	NewHandler(grpcServer, service)
+	NewPricingHandler(grpcServer, NewPricingService(store, loc))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	sweeper := NewSweeper(service, interval)
+	go sweeper.Start(ctx)
 
-	log.Println("gRPC server listening on", grpcAddr)
+	slog.Info("grpc server listening", "addr", grpcAddr)
 
 	if err := grpcServer.Serve(l); err != nil {
-		log.Fatal(err.Error())
+		logging.Fatal("grpc server stopped", "error", err)
 	}
 }
@@ -0,0 +1,2 @@
+DROP TABLE booked_items;
+DROP TABLE stock_items;
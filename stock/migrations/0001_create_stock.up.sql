@@ -0,0 +1,24 @@
+-- Before migrations, the stock_items and booked_items tables were created
+-- by hand in ./db/db.db. This baseline adopts them where they already exist.
+CREATE TABLE IF NOT EXISTS stock_items (
+    id          TEXT PRIMARY KEY,
+    quantity    INTEGER NOT NULL DEFAULT 0,
+    name        TEXT NOT NULL DEFAULT '',
+    price_id    TEXT NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    img_path    TEXT NOT NULL DEFAULT '',
+    created_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at  DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS booked_items (
+    booking_id TEXT PRIMARY KEY,
+    item_id    TEXT NOT NULL,
+    quantity   INTEGER NOT NULL CHECK (quantity > 0),
+    order_id   TEXT NOT NULL,
+    expires_at DATETIME NOT NULL,
+    created_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_booked_items_item_id ON booked_items (item_id, expires_at);
+CREATE INDEX IF NOT EXISTS idx_booked_items_order_id ON booked_items (order_id);
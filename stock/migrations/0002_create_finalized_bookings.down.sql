@@ -0,0 +1 @@
+DROP TABLE finalized_bookings;
@@ -0,0 +1,4 @@
+CREATE TABLE finalized_bookings (
+    order_id     TEXT PRIMARY KEY,
+    finalized_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,2 @@
+ALTER TABLE stock_items ADD COLUMN price_amount INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE stock_items ADD COLUMN price_currency TEXT NOT NULL DEFAULT '';
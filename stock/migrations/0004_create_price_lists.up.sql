@@ -0,0 +1,20 @@
+CREATE TABLE price_lists (
+    id             TEXT PRIMARY KEY,
+    name           TEXT NOT NULL DEFAULT '',
+    currency       TEXT NOT NULL,
+    priority       INTEGER NOT NULL DEFAULT 0,
+    effective_from DATETIME,
+    effective_to   DATETIME,
+    daily_from     TEXT NOT NULL DEFAULT '',
+    daily_to       TEXT NOT NULL DEFAULT '',
+    created_at     DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE price_list_entries (
+    price_list_id TEXT NOT NULL,
+    price_id      TEXT NOT NULL,
+    amount        INTEGER NOT NULL CHECK (amount >= 0),
+    PRIMARY KEY (price_list_id, price_id)
+);
+
+CREATE INDEX idx_price_list_entries_price_id ON price_list_entries (price_id);
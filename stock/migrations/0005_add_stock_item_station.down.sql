@@ -0,0 +1 @@
+ALTER TABLE stock_items DROP COLUMN station;
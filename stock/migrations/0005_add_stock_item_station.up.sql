@@ -0,0 +1 @@
+ALTER TABLE stock_items ADD COLUMN station TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,2 @@
+DROP TABLE finalized_booking_items;
+ALTER TABLE finalized_bookings DROP COLUMN returned_at;
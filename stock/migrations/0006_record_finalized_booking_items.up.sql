@@ -0,0 +1,8 @@
+ALTER TABLE finalized_bookings ADD COLUMN returned_at DATETIME;
+
+CREATE TABLE finalized_booking_items (
+    order_id TEXT NOT NULL REFERENCES finalized_bookings (order_id),
+    item_id  TEXT NOT NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    PRIMARY KEY (order_id, item_id)
+);
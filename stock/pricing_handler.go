@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/grpc"
+)
+
+type PricingHandler struct {
+	pb.UnimplementedPricingServiceServer
+	service PricingService
+}
+
+func NewPricingHandler(s *grpc.Server, service PricingService) *PricingHandler {
+	h := &PricingHandler{
+		service: service,
+	}
+	pb.RegisterPricingServiceServer(s, h)
+	return h
+}
+
+func (h *PricingHandler) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.PriceList, error) {
+	return h.service.CreatePriceList(ctx, req)
+}
+
+func (h *PricingHandler) SetPrices(ctx context.Context, req *pb.SetPricesRequest) (*pb.PriceList, error) {
+	return h.service.SetPrices(ctx, req)
+}
+
+func (h *PricingHandler) ListPriceLists(ctx context.Context, req *pb.ListPriceListsRequest) (*pb.ListPriceListsResponse, error) {
+	lists, err := h.service.ListPriceLists(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ListPriceListsResponse{PriceLists: lists}, nil
+}
+
+func (h *PricingHandler) DeletePriceList(ctx context.Context, req *pb.DeletePriceListRequest) (*pb.DeletePriceListResponse, error) {
+	if err := h.service.DeletePriceList(ctx, req.ID); err != nil {
+		return nil, err
+	}
+	return &pb.DeletePriceListResponse{}, nil
+}
+
+func (h *PricingHandler) ResolvePrices(ctx context.Context, req *pb.ResolvePricesRequest) (*pb.ResolvePricesResponse, error) {
+	prices, err := h.service.ResolvePrices(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return &pb.ResolvePricesResponse{Prices: prices}, nil
+}
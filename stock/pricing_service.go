@@ -0,0 +1,176 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+)
+
+type PricingService interface {
+	CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.PriceList, error)
+	SetPrices(ctx context.Context, req *pb.SetPricesRequest) (*pb.PriceList, error)
+	ListPriceLists(ctx context.Context) ([]*pb.PriceList, error)
+	DeletePriceList(ctx context.Context, id string) error
+	ResolvePrices(ctx context.Context, req *pb.ResolvePricesRequest) ([]*pb.ResolvedPrice, error)
+}
+
+type pricingService struct {
+	store PricingStore
+	// loc is the time zone daily windows such as happy hours are given in.
+	loc *time.Location
+	now func() time.Time
+}
+
+func NewPricingService(store PricingStore, loc *time.Location) *pricingService {
+	return &pricingService{store: store, loc: loc, now: time.Now}
+}
+
+func (s *pricingService) CreatePriceList(ctx context.Context, req *pb.CreatePriceListRequest) (*pb.PriceList, error) {
+	list := req.PriceList
+	if list == nil {
+		return nil, common.InvalidArgument("price list", "is required")
+	}
+	if list.ID == "" {
+		return nil, common.InvalidArgument("ID", "is required")
+	}
+	if len(list.Currency) != 3 {
+		return nil, common.InvalidArgument("currency", "must be a three-letter ISO 4217 code")
+	}
+	if list.EffectiveFrom != nil && list.EffectiveTo != nil && !list.EffectiveTo.AsTime().After(list.EffectiveFrom.AsTime()) {
+		return nil, common.InvalidArgument("effective to", "must be after effective from")
+	}
+	if (list.DailyFrom == "") != (list.DailyTo == "") {
+		return nil, common.InvalidArgument("daily window", "needs both a start and an end")
+	}
+	if list.DailyFrom != "" {
+		from, err := parseTimeOfDay("daily from", list.DailyFrom)
+		if err != nil {
+			return nil, err
+		}
+		to, err := parseTimeOfDay("daily to", list.DailyTo)
+		if err != nil {
+			return nil, err
+		}
+		if from == to {
+			return nil, common.InvalidArgument("daily window", "cannot be empty")
+		}
+	}
+	if err := validatePriceEntries(list.Entries); err != nil {
+		return nil, err
+	}
+
+	return s.store.CreatePriceList(ctx, list)
+}
+
+func (s *pricingService) SetPrices(ctx context.Context, req *pb.SetPricesRequest) (*pb.PriceList, error) {
+	if req.PriceListID == "" {
+		return nil, common.InvalidArgument("price list ID", "is required")
+	}
+	if len(req.Entries) == 0 {
+		return nil, common.InvalidArgument("entries", "must contain at least one price")
+	}
+	if err := validatePriceEntries(req.Entries); err != nil {
+		return nil, err
+	}
+
+	return s.store.SetPrices(ctx, req.PriceListID, req.Entries)
+}
+
+func (s *pricingService) ListPriceLists(ctx context.Context) ([]*pb.PriceList, error) {
+	return s.store.ListPriceLists(ctx)
+}
+
+func (s *pricingService) DeletePriceList(ctx context.Context, id string) error {
+	return s.store.DeletePriceList(ctx, id)
+}
+
+// ResolvePrices picks, for every requested price ID, the amount of the best
+// price list in effect at req.At. IDs no list prices are left out.
+func (s *pricingService) ResolvePrices(ctx context.Context, req *pb.ResolvePricesRequest) ([]*pb.ResolvedPrice, error) {
+	if len(req.PriceIDs) > common.MaxPageSize {
+		return nil, common.InvalidArgument("price IDs", fmt.Sprintf("cannot contain more than %d items", common.MaxPageSize))
+	}
+
+	at := s.now()
+	if req.At != nil {
+		at = req.At.AsTime()
+	}
+
+	candidates, err := s.store.PriceCandidates(ctx, req.PriceIDs, at, req.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	minute := minuteOfDay(at.In(s.loc))
+	best := make(map[string]*pb.ResolvedPrice, len(req.PriceIDs))
+	for _, c := range candidates {
+		if _, ok := best[c.PriceID]; ok {
+			continue
+		}
+		if !inDailyWindow(minute, c.DailyFrom, c.DailyTo) {
+			continue
+		}
+		best[c.PriceID] = &pb.ResolvedPrice{PriceID: c.PriceID, Price: c.Price, PriceListID: c.PriceListID}
+	}
+
+	prices := make([]*pb.ResolvedPrice, 0, len(best))
+	for _, id := range req.PriceIDs {
+		if p, ok := best[id]; ok {
+			prices = append(prices, p)
+			delete(best, id)
+		}
+	}
+
+	return prices, nil
+}
+
+func validatePriceEntries(entries []*pb.PriceListEntry) error {
+	for _, e := range entries {
+		if e.PriceID == "" {
+			return common.InvalidArgument("price ID", "is required")
+		}
+		if e.Amount < 0 {
+			return common.InvalidArgument("amount", fmt.Sprintf("of price %s cannot be negative", e.PriceID))
+		}
+	}
+	return nil
+}
+
+// parseTimeOfDay reads an "HH:MM" time into minutes since midnight.
+func parseTimeOfDay(field, s string) (int, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, common.InvalidArgument(field, "must be a time of day formatted HH:MM")
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func minuteOfDay(t time.Time) int {
+	return t.Hour()*60 + t.Minute()
+}
+
+// inDailyWindow reports whether minute falls within [from, to). A window
+// whose end is before its start wraps around midnight, and no window at all
+// covers the whole day.
+func inDailyWindow(minute int, from, to string) bool {
+	if from == "" || to == "" {
+		return true
+	}
+
+	start, err := parseTimeOfDay("daily from", from)
+	if err != nil {
+		return false
+	}
+	end, err := parseTimeOfDay("daily to", to)
+	if err != nil {
+		return false
+	}
+
+	if start <= end {
+		return minute >= start && minute < end
+	}
+	return minute >= start || minute < end
+}
@@ -0,0 +1,302 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	common "github.com/kiriyms/oms_go-common"
+	pb "github.com/kiriyms/oms_go-common/api"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type PricingStore interface {
+	CreatePriceList(ctx context.Context, list *pb.PriceList) (*pb.PriceList, error)
+	SetPrices(ctx context.Context, priceListID string, entries []*pb.PriceListEntry) (*pb.PriceList, error)
+	GetPriceList(ctx context.Context, id string) (*pb.PriceList, error)
+	ListPriceLists(ctx context.Context) ([]*pb.PriceList, error)
+	DeletePriceList(ctx context.Context, id string) error
+	// PriceCandidates returns, for every price ID, the entries of the lists
+	// effective at the given time, best candidate first. Time-of-day windows
+	// are left to the caller.
+	PriceCandidates(ctx context.Context, priceIDs []string, at time.Time, currency string) ([]PriceCandidate, error)
+}
+
+// PriceCandidate is an entry of a price list that may apply to a price ID.
+type PriceCandidate struct {
+	PriceID     string
+	PriceListID string
+	Price       *pb.Money
+	DailyFrom   string
+	DailyTo     string
+}
+
+const selectPriceListsSQL = `
+	SELECT id, name, currency, priority, effective_from, effective_to, daily_from, daily_to
+	FROM price_lists
+`
+
+func (s *store) CreatePriceList(ctx context.Context, list *pb.PriceList) (*pb.PriceList, error) {
+	slog.InfoContext(ctx, "creating price list", "price_list_id", list.ID, "currency", list.Currency)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM price_lists WHERE id = ?)`, list.ID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check price list: %w", err)
+	}
+	if exists {
+		return nil, common.Conflict("price list", list.ID, fmt.Sprintf("price list %s already exists", list.ID))
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO price_lists (id, name, currency, priority, effective_from, effective_to, daily_from, daily_to)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		list.ID,
+		list.Name,
+		list.Currency,
+		list.Priority,
+		nullableTime(list.EffectiveFrom),
+		nullableTime(list.EffectiveTo),
+		list.DailyFrom,
+		list.DailyTo,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to insert price list: %w", err)
+	}
+
+	if err := upsertPriceEntries(ctx, tx, list.ID, list.Entries); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetPriceList(ctx, list.ID)
+}
+
+// SetPrices adds entries to a price list, replacing the amounts of price IDs
+// it already has.
+func (s *store) SetPrices(ctx context.Context, priceListID string, entries []*pb.PriceListEntry) (*pb.PriceList, error) {
+	slog.InfoContext(ctx, "setting prices", "price_list_id", priceListID, "entries", len(entries))
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var exists bool
+	err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM price_lists WHERE id = ?)`, priceListID).Scan(&exists)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check price list: %w", err)
+	}
+	if !exists {
+		return nil, common.NotFound("price list", priceListID)
+	}
+
+	if err := upsertPriceEntries(ctx, tx, priceListID, entries); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetPriceList(ctx, priceListID)
+}
+
+func upsertPriceEntries(ctx context.Context, tx *sql.Tx, priceListID string, entries []*pb.PriceListEntry) error {
+	for _, e := range entries {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO price_list_entries (price_list_id, price_id, amount)
+			VALUES (?, ?, ?)
+			ON CONFLICT(price_list_id, price_id)
+			DO UPDATE SET amount = excluded.amount
+		`, priceListID, e.PriceID, e.Amount)
+		if err != nil {
+			return fmt.Errorf("failed to set price %s: %w", e.PriceID, err)
+		}
+	}
+	return nil
+}
+
+func (s *store) GetPriceList(ctx context.Context, id string) (*pb.PriceList, error) {
+	list, err := scanPriceList(s.db.QueryRowContext(ctx, selectPriceListsSQL+`
+		WHERE id = ?
+	`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("price list", id)
+		}
+		return nil, fmt.Errorf("failed to fetch price list: %w", err)
+	}
+
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT price_id, amount
+		FROM price_list_entries
+		WHERE price_list_id = ?
+		ORDER BY price_id
+	`, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch price list entries: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var e pb.PriceListEntry
+		if err := rows.Scan(&e.PriceID, &e.Amount); err != nil {
+			return nil, fmt.Errorf("failed to scan price list entry: %w", err)
+		}
+		list.Entries = append(list.Entries, &e)
+	}
+
+	return list, rows.Err()
+}
+
+// ListPriceLists returns every price list without its entries.
+func (s *store) ListPriceLists(ctx context.Context) ([]*pb.PriceList, error) {
+	rows, err := s.db.QueryContext(ctx, selectPriceListsSQL+`
+		ORDER BY priority DESC, id
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list price lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []*pb.PriceList{}
+	for rows.Next() {
+		list, err := scanPriceList(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan price list: %w", err)
+		}
+		lists = append(lists, list)
+	}
+
+	return lists, rows.Err()
+}
+
+func (s *store) DeletePriceList(ctx context.Context, id string) error {
+	slog.InfoContext(ctx, "deleting price list", "price_list_id", id)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `DELETE FROM price_lists WHERE id = ?`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete price list: %w", err)
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return common.NotFound("price list", id)
+	}
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM price_list_entries WHERE price_list_id = ?`, id); err != nil {
+		return fmt.Errorf("failed to delete price list entries: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+func (s *store) PriceCandidates(ctx context.Context, priceIDs []string, at time.Time, currency string) ([]PriceCandidate, error) {
+	if len(priceIDs) == 0 {
+		return nil, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(priceIDs)), ",")
+	args := make([]any, 0, len(priceIDs)+4)
+	for _, id := range priceIDs {
+		args = append(args, id)
+	}
+	args = append(args, sqliteTime(at), sqliteTime(at), currency, currency)
+
+	// Lists without a start date sort last among lists of equal priority,
+	// since SQLite orders NULLs first.
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT e.price_id, l.id, l.currency, e.amount, l.daily_from, l.daily_to
+		FROM price_list_entries e
+		JOIN price_lists l ON l.id = e.price_list_id
+		WHERE e.price_id IN (`+placeholders+`)
+		  AND (l.effective_from IS NULL OR l.effective_from <= ?)
+		  AND (l.effective_to IS NULL OR l.effective_to > ?)
+		  AND (? = '' OR l.currency = ?)
+		ORDER BY e.price_id, l.priority DESC, l.effective_from DESC, l.id
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch prices: %w", err)
+	}
+	defer rows.Close()
+
+	var candidates []PriceCandidate
+	for rows.Next() {
+		var (
+			c     PriceCandidate
+			price pb.Money
+		)
+		if err := rows.Scan(&c.PriceID, &c.PriceListID, &price.Currency, &price.Amount, &c.DailyFrom, &c.DailyTo); err != nil {
+			return nil, fmt.Errorf("failed to scan price: %w", err)
+		}
+		c.Price = &price
+		candidates = append(candidates, c)
+	}
+
+	return candidates, rows.Err()
+}
+
+func scanPriceList(row rowScanner) (*pb.PriceList, error) {
+	var (
+		list          pb.PriceList
+		effectiveFrom sql.NullTime
+		effectiveTo   sql.NullTime
+	)
+
+	err := row.Scan(
+		&list.ID,
+		&list.Name,
+		&list.Currency,
+		&list.Priority,
+		&effectiveFrom,
+		&effectiveTo,
+		&list.DailyFrom,
+		&list.DailyTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if effectiveFrom.Valid {
+		list.EffectiveFrom = timestamppb.New(effectiveFrom.Time)
+	}
+	if effectiveTo.Valid {
+		list.EffectiveTo = timestamppb.New(effectiveTo.Time)
+	}
+	return &list, nil
+}
+
+func nullableTime(ts *timestamppb.Timestamp) any {
+	if ts == nil {
+		return nil
+	}
+	return sqliteTime(ts.AsTime())
+}
+
+// sqliteTime formats t the way SQLite's CURRENT_TIMESTAMP does, so that the
+// two compare correctly as text.
+func sqliteTime(t time.Time) string {
+	return t.UTC().Format(time.DateTime)
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
+	"github.com/segmentio/kafka-go"
+)
+
+type Producer struct {
+	writer *kafka.Writer
+}
+
+func NewProducer(brokerURL string) *Producer {
+	writer := kafka.NewWriter(kafka.WriterConfig{
+		Brokers:  []string{brokerURL},
+		Topic:    "stock.booking_expired",
+		Balancer: &kafka.LeastBytes{},
+	})
+
+	return &Producer{writer: writer}
+}
+
+func (p *Producer) PublishBookingExpired(ctx context.Context, event *pb.BookingExpiredEvent) error {
+	valueBytes, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+
+	msg := kafka.Message{
+		Key:   []byte(event.OrderID),
+		Value: valueBytes,
+		Headers: []kafka.Header{
+			{Key: logging.CorrelationHeader, Value: []byte(logging.CorrelationID(ctx))},
+		},
+	}
+
+	err = p.writer.WriteMessages(ctx, msg)
+	if err != nil {
+		slog.ErrorContext(ctx, "failed to write message", "topic", "stock.booking_expired", "error", err)
+		return err
+	}
+
+	slog.InfoContext(ctx, "published event", "topic", "stock.booking_expired", "key", event.OrderID)
+	return nil
+}
+
+func (p *Producer) Close() error {
+	return p.writer.Close()
+}
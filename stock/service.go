@@ -2,11 +2,19 @@ package main
 
 import (
 	"context"
-	"log"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/logging"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const maxBookingTTL = 24 * time.Hour
+
 type StockService interface {
 	AddStockItem(ctx context.Context, item *pb.AddStockItemRequest) (*pb.StockItem, error)
 	BookStockItems(ctx context.Context, item *pb.BookItemsRequest) ([]*pb.ItemWithQuantity, error)
@@ -14,18 +22,40 @@ type StockService interface {
 	RemoveStockItem(ctx context.Context, itemID string) (*pb.StockItem, error)
 	VerifyStock(ctx context.Context, req *pb.VerifyStockRequest) (*pb.VerifyStockResponse, error)
 	GetStockItem(ctx context.Context, req *pb.GetStockItemRequest) (*pb.StockItem, error)
+	GetStockItems(ctx context.Context, req *pb.GetStockItemsRequest) ([]*pb.StockItem, error)
+	ListStockItems(ctx context.Context, req *pb.ListStockItemsRequest) (*pb.ListStockItemsResponse, error)
+	AdjustStockQuantity(ctx context.Context, req *pb.AdjustStockQuantityRequest) (*pb.StockItem, error)
 	FinalizeBooking(ctx context.Context, orderID string) error
+	ReturnFinalizedItems(ctx context.Context, orderID string) ([]*pb.ItemWithQuantity, error)
+	SweepExpiredBookings(ctx context.Context) error
 }
 
 type service struct {
-	store StockStore
+	store      StockStore
+	producer   *Producer
+	bookingTTL time.Duration
 }
 
-func NewStockService(store StockStore) *service {
-	return &service{store: store}
+func NewStockService(store StockStore, producer *Producer, bookingTTL time.Duration) *service {
+	return &service{store: store, producer: producer, bookingTTL: bookingTTL}
 }
 
 func (s *service) AddStockItem(ctx context.Context, req *pb.AddStockItemRequest) (*pb.StockItem, error) {
+	if req.ID == "" {
+		return nil, common.InvalidArgument("ID", "is required")
+	}
+	if req.Quantity < 0 {
+		return nil, common.InvalidArgument("quantity", "cannot be negative")
+	}
+	if req.Price != nil {
+		if len(req.Price.Currency) != 3 {
+			return nil, common.InvalidArgument("price currency", "must be a three-letter ISO 4217 code")
+		}
+		if req.Price.Amount < 0 {
+			return nil, common.InvalidArgument("price amount", "cannot be negative")
+		}
+	}
+
 	stockItem := &pb.StockItem{
 		ID:          req.ID,
 		Quantity:    req.Quantity,
@@ -33,22 +63,32 @@ func (s *service) AddStockItem(ctx context.Context, req *pb.AddStockItemRequest)
 		PriceID:     req.PriceID,
 		Description: req.Description,
 		ImgPath:     req.ImgPath,
+		Price:       req.Price,
+		Station:     strings.ToLower(strings.TrimSpace(req.Station)),
 	}
 
 	return s.store.AddStockItem(ctx, stockItem)
 }
 
 func (s *service) BookStockItems(ctx context.Context, req *pb.BookItemsRequest) ([]*pb.ItemWithQuantity, error) {
+	ttl := s.bookingTTL
+	if req.TTL != nil {
+		ttl = req.TTL.AsDuration()
+	}
+	if ttl <= 0 || ttl > maxBookingTTL {
+		return nil, common.InvalidArgument("TTL", fmt.Sprintf("must be between 0 and %s, got %s", maxBookingTTL, ttl))
+	}
+
 	var bookedItems []*pb.ItemWithQuantity
 	for _, item := range req.Items {
-		bItem, err := s.store.BookStockItem(ctx, item.ID, item.Quantity, req.OrderID)
+		bItem, err := s.store.BookStockItem(ctx, item.ID, item.Quantity, req.OrderID, ttl)
 		if err != nil {
 			return nil, err
 		}
 		bookedItems = append(bookedItems, bItem)
 	}
 
-	log.Printf("Booked items: %v", bookedItems)
+	slog.InfoContext(ctx, "booked items", "order_id", req.OrderID, "items", bookedItems, "ttl", ttl)
 
 	return bookedItems, nil
 }
@@ -56,7 +96,7 @@ func (s *service) BookStockItems(ctx context.Context, req *pb.BookItemsRequest)
 func (s *service) ReleaseBookItems(ctx context.Context, req *pb.ReleaseBookedItemsRequest) ([]*pb.ItemWithQuantity, error) {
 	var releasedItems []*pb.ItemWithQuantity
 	for _, item := range req.Items {
-		rItems, err := s.store.ReleaseBookItem(ctx, item.ID, item.Quantity)
+		rItems, err := s.store.ReleaseBookItem(ctx, req.OrderID, item.ID, item.Quantity)
 		if err != nil {
 			return nil, err
 		}
@@ -70,7 +110,7 @@ func (s *service) RemoveStockItem(ctx context.Context, itemID string) (*pb.Stock
 }
 
 func (s *service) VerifyStock(ctx context.Context, req *pb.VerifyStockRequest) (*pb.VerifyStockResponse, error) {
-	log.Printf("Validating stock request: %v", req.Items)
+	slog.DebugContext(ctx, "verifying stock", "items", req.Items)
 	return s.store.VerifyStock(ctx, req.Items), nil
 }
 
@@ -78,6 +118,104 @@ func (s *service) GetStockItem(ctx context.Context, req *pb.GetStockItemRequest)
 	return s.store.GetStockItem(ctx, req.ID)
 }
 
+func (s *service) GetStockItems(ctx context.Context, req *pb.GetStockItemsRequest) ([]*pb.StockItem, error) {
+	if len(req.IDs) > common.MaxPageSize {
+		return nil, common.InvalidArgument("IDs", fmt.Sprintf("cannot contain more than %d items", common.MaxPageSize))
+	}
+
+	return s.store.GetStockItems(ctx, req.IDs)
+}
+
+func (s *service) ListStockItems(ctx context.Context, req *pb.ListStockItemsRequest) (*pb.ListStockItemsResponse, error) {
+	pageSize, err := common.PageSize(req.PageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := pb.StockItemOrder_name[int32(req.OrderBy)]; !ok {
+		return nil, common.InvalidArgument("order by", fmt.Sprintf("has unknown value %d", req.OrderBy))
+	}
+
+	q := StockItemQuery{
+		NameQuery:   req.NameQuery,
+		InStockOnly: req.InStockOnly,
+		OrderBy:     req.OrderBy,
+		PageSize:    pageSize,
+	}
+
+	if req.PageToken != "" {
+		var c stockCursor
+		if err := common.DecodePageToken(req.PageToken, &c); err != nil {
+			return nil, err
+		}
+		if c.Order != req.OrderBy {
+			return nil, common.InvalidArgument("page token", "was issued for a different order")
+		}
+		q.After = &c
+	}
+
+	items, next, err := s.store.ListStockItems(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &pb.ListStockItemsResponse{Items: items}
+	if next != nil {
+		resp.NextPageToken, err = common.EncodePageToken(next)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return resp, nil
+}
+
+func (s *service) AdjustStockQuantity(ctx context.Context, req *pb.AdjustStockQuantityRequest) (*pb.StockItem, error) {
+	if req.ID == "" {
+		return nil, common.InvalidArgument("ID", "is required")
+	}
+	if req.Delta == 0 {
+		return nil, common.InvalidArgument("delta", "cannot be zero")
+	}
+
+	return s.store.AdjustStockQuantity(ctx, req.ID, req.Delta)
+}
+
 func (s *service) FinalizeBooking(ctx context.Context, orderID string) error {
 	return s.store.FinalizeBooking(ctx, orderID)
 }
+
+func (s *service) ReturnFinalizedItems(ctx context.Context, orderID string) ([]*pb.ItemWithQuantity, error) {
+	return s.store.ReturnFinalizedItems(ctx, orderID)
+}
+
+// SweepExpiredBookings publishes a stock.booking_expired event for every
+// order with expired bookings and then deletes those bookings.
+func (s *service) SweepExpiredBookings(ctx context.Context) error {
+	expired, err := s.store.ListExpiredBookings(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, b := range expired {
+		event := &pb.BookingExpiredEvent{
+			OrderID:   b.OrderID,
+			Items:     b.Items,
+			ExpiredAt: timestamppb.New(b.ExpiredAt),
+		}
+		// Expired bookings outlive the request that made them, so each
+		// order gets a fresh correlation ID here.
+		evCtx := logging.EnsureCorrelationID(ctx, "")
+		if err := s.producer.PublishBookingExpired(evCtx, event); err != nil {
+			return err
+		}
+
+		if err := s.store.DeleteBookings(ctx, b.BookingIDs); err != nil {
+			return err
+		}
+
+		slog.InfoContext(evCtx, "purged expired bookings", "order_id", b.OrderID, "bookings", len(b.BookingIDs))
+	}
+
+	return nil
+}
@@ -3,57 +3,101 @@ package main
 import (
 	"context"
 	"database/sql"
+	"embed"
 	"fmt"
-	"log"
+	"log/slog"
+	"strings"
 	"time"
 
+	common "github.com/kiriyms/oms_go-common"
 	pb "github.com/kiriyms/oms_go-common/api"
+	"github.com/kiriyms/oms_go-common/migrate"
 	_ "github.com/mattn/go-sqlite3"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type StockStore interface {
 	AddStockItem(ctx context.Context, item *pb.StockItem) (*pb.StockItem, error)
-	BookStockItem(ctx context.Context, itemID string, quantity int32, orderID string) (*pb.ItemWithQuantity, error)
-	ReleaseBookItem(ctx context.Context, itemID string, quantity int32) (*pb.ItemWithQuantity, error)
+	BookStockItem(ctx context.Context, itemID string, quantity int32, orderID string, ttl time.Duration) (*pb.ItemWithQuantity, error)
+	ReleaseBookItem(ctx context.Context, orderID string, itemID string, quantity int32) (*pb.ItemWithQuantity, error)
 	RemoveStockItem(ctx context.Context, itemID string) (*pb.StockItem, error)
 	VerifyStock(ctx context.Context, items []*pb.ItemWithQuantity) *pb.VerifyStockResponse
 	GetStockItem(ctx context.Context, itemID string) (*pb.StockItem, error)
+	GetStockItems(ctx context.Context, itemIDs []string) ([]*pb.StockItem, error)
+	ListStockItems(ctx context.Context, q StockItemQuery) ([]*pb.StockItem, *stockCursor, error)
+	AdjustStockQuantity(ctx context.Context, itemID string, delta int32) (*pb.StockItem, error)
 	FinalizeBooking(ctx context.Context, orderID string) error
+	ReturnFinalizedItems(ctx context.Context, orderID string) ([]*pb.ItemWithQuantity, error)
+	ListExpiredBookings(ctx context.Context) ([]ExpiredBooking, error)
+	DeleteBookings(ctx context.Context, bookingIDs []string) error
 	Close() error
 }
 
+// StockItemQuery selects a page of stock items.
+type StockItemQuery struct {
+	NameQuery   string
+	InStockOnly bool
+	OrderBy     pb.StockItemOrder
+	PageSize    int
+	// After is the last item of the previous page, if any.
+	After *stockCursor
+}
+
+// stockCursor identifies the position of an item in a listing. Only the
+// field matching Order is set besides ID.
+type stockCursor struct {
+	Order     pb.StockItemOrder `json:"o"`
+	Name      string            `json:"n,omitempty"`
+	Available int32             `json:"a,omitempty"`
+	ID        string            `json:"i"`
+}
+
+// ExpiredBooking groups the expired bookings of a single order.
+type ExpiredBooking struct {
+	OrderID    string
+	BookingIDs []string
+	Items      []*pb.ItemWithQuantity
+	ExpiredAt  time.Time
+}
+
 type store struct {
 	db *sql.DB
 }
 
+//go:embed migrations/*.sql
+var migrationsFS embed.FS
+
 func NewStore(dbPath string) (*store, error) {
-	db, err := sql.Open("sqlite3", dbPath)
+	db, err := migrate.Open("sqlite3", dbPath, migrationsFS, "migrations")
 	if err != nil {
 		return nil, err
 	}
+	return &store{db: db}, nil
+}
 
-	if err := db.Ping(); err != nil {
-		return nil, err
-	}
-
-	s := &store{db: db}
-	return s, nil
+// RunMigrationCommand prints the pending migrations or rolls back the given
+// number of them without starting the service.
+func RunMigrationCommand(dbPath string, printPending bool, down int) error {
+	return migrate.RunCommand("sqlite3", dbPath, migrationsFS, "migrations", printPending, down)
 }
 
 func (s *store) AddStockItem(ctx context.Context, item *pb.StockItem) (*pb.StockItem, error) {
-	log.Printf("Adding stock item: %+v", item)
+	slog.InfoContext(ctx, "adding stock item", "item_id", item.ID, "quantity", item.Quantity)
 
 	_, err := s.db.ExecContext(ctx, `
-		INSERT INTO stock_items (id, quantity, name, price_id, description, img_path, created_at, updated_at)
-		VALUES (?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		INSERT INTO stock_items (id, quantity, name, price_id, description, img_path, price_amount, price_currency, station, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
 		ON CONFLICT(id)
 		DO UPDATE SET
-			quantity   = stock_items.quantity + excluded.quantity,
-			name       = excluded.name,
-			price_id   = excluded.price_id,
-			description= excluded.description,
-			img_path   = excluded.img_path,
-			updated_at = CURRENT_TIMESTAMP
+			quantity       = stock_items.quantity + excluded.quantity,
+			name           = excluded.name,
+			price_id       = excluded.price_id,
+			description    = excluded.description,
+			img_path       = excluded.img_path,
+			price_amount   = excluded.price_amount,
+			price_currency = excluded.price_currency,
+			station        = excluded.station,
+			updated_at     = CURRENT_TIMESTAMP
 	`,
 		item.ID,
 		item.Quantity,
@@ -61,20 +105,23 @@ func (s *store) AddStockItem(ctx context.Context, item *pb.StockItem) (*pb.Stock
 		item.PriceID,
 		item.Description,
 		item.ImgPath,
+		item.GetPrice().GetAmount(),
+		item.GetPrice().GetCurrency(),
+		item.Station,
 	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to add stock item: %w", err)
 	}
 
-	return item, nil
+	return s.GetStockItem(ctx, item.ID)
 }
 
-func (s *store) BookStockItem(ctx context.Context, itemID string, quantity int32, orderID string) (*pb.ItemWithQuantity, error) {
-	log.Printf("Booking stock item %s qty %d", itemID, quantity)
+func (s *store) BookStockItem(ctx context.Context, itemID string, quantity int32, orderID string, ttl time.Duration) (*pb.ItemWithQuantity, error) {
+	slog.DebugContext(ctx, "booking stock item", "order_id", orderID, "item_id", itemID, "quantity", quantity)
 
 	if quantity <= 0 {
-		return nil, fmt.Errorf("quantity must be positive")
+		return nil, common.InvalidArgument("quantity", "must be positive")
 	}
 
 	tx, err := s.db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
@@ -83,6 +130,17 @@ func (s *store) BookStockItem(ctx context.Context, itemID string, quantity int32
 	}
 	defer tx.Rollback()
 
+	// Booking the same item for the same order again replaces the previous
+	// booking, so callers can safely retry after a crash or timeout.
+	_, err = tx.ExecContext(ctx, `
+		DELETE FROM booked_items
+		WHERE order_id = ?
+		  AND item_id = ?
+	`, orderID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to replace previous booking: %w", err)
+	}
+
 	var stockQty int32
 	err = tx.QueryRowContext(ctx, `
 		SELECT quantity
@@ -91,28 +149,27 @@ func (s *store) BookStockItem(ctx context.Context, itemID string, quantity int32
 	`, itemID).Scan(&stockQty)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("item %s not found", itemID)
+			return nil, common.NotFound("stock item", itemID)
 		}
 		return nil, err
 	}
 
-	var bookedQty int32
-	err = tx.QueryRowContext(ctx, `
-		SELECT COALESCE(SUM(quantity), 0)
-		FROM booked_items
-		WHERE item_id = ?
-		  AND expires_at > CURRENT_TIMESTAMP
-	`, itemID).Scan(&bookedQty)
+	bookedQty, err := bookedQuantity(ctx, tx, itemID)
 	if err != nil {
 		return nil, err
 	}
 
 	available := stockQty - bookedQty
 	if available < quantity {
-		return nil, fmt.Errorf("insufficient stock: available=%d requested=%d", available, quantity)
+		return nil, common.InsufficientStock(common.StockShortage{
+			ItemID:    itemID,
+			Requested: quantity,
+			Available: max(available, 0),
+		})
 	}
 
-	expiresAt := time.Now().Add(15 * time.Minute)
+	// Stored in UTC so it compares correctly against CURRENT_TIMESTAMP.
+	expiresAt := time.Now().UTC().Add(ttl)
 
 	_, err = tx.ExecContext(ctx, `
 		INSERT INTO booked_items (booking_id, item_id, quantity, order_id, expires_at, created_at)
@@ -137,15 +194,16 @@ func (s *store) BookStockItem(ctx context.Context, itemID string, quantity int32
 	}, nil
 }
 
-func (s *store) ReleaseBookItem(ctx context.Context, itemID string, quantity int32) (*pb.ItemWithQuantity, error) {
-	log.Printf("Releasing booking for item %s qty %d", itemID, quantity)
+func (s *store) ReleaseBookItem(ctx context.Context, orderID string, itemID string, quantity int32) (*pb.ItemWithQuantity, error) {
+	slog.InfoContext(ctx, "releasing booking", "order_id", orderID, "item_id", itemID, "quantity", quantity)
 
 	if quantity <= 0 {
 		_, err := s.db.ExecContext(ctx, `
 			DELETE FROM booked_items
-			WHERE item_id = ?
-		`, itemID)
-		return nil, err
+			WHERE order_id = ?
+			  AND item_id = ?
+		`, orderID, itemID)
+		return &pb.ItemWithQuantity{ID: itemID}, err
 	}
 
 	tx, err := s.db.BeginTx(ctx, nil)
@@ -157,9 +215,10 @@ func (s *store) ReleaseBookItem(ctx context.Context, itemID string, quantity int
 	rows, err := tx.QueryContext(ctx, `
 		SELECT booking_id, quantity
 		FROM booked_items
-		WHERE item_id = ?
+		WHERE order_id = ?
+		  AND item_id = ?
 		ORDER BY created_at ASC
-	`, itemID)
+	`, orderID, itemID)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +266,7 @@ func (s *store) ReleaseBookItem(ctx context.Context, itemID string, quantity int
 }
 
 func (s *store) RemoveStockItem(ctx context.Context, itemID string) (*pb.StockItem, error) {
-	log.Printf("Removing stock item %s", itemID)
+	slog.InfoContext(ctx, "removing stock item", "item_id", itemID)
 
 	item, err := s.GetStockItem(ctx, itemID)
 	if err != nil {
@@ -246,17 +305,14 @@ func (s *store) VerifyStock(ctx context.Context, items []*pb.ItemWithQuantity) *
 				ID:       item.ID,
 				Quantity: item.Quantity,
 			})
+			resp.Shortages = append(resp.Shortages, &pb.StockShortage{
+				ItemID:    item.ID,
+				Requested: item.Quantity,
+			})
 			continue
 		}
 
-		var bookedQty int32
-		err = s.db.QueryRowContext(ctx, `
-			SELECT COALESCE(SUM(quantity), 0)
-			FROM booked_items
-			WHERE item_id = ?
-			  AND expires_at > CURRENT_TIMESTAMP
-		`, item.ID).Scan(&bookedQty)
-
+		bookedQty, err := bookedQuantity(ctx, s.db, item.ID)
 		if err != nil {
 			resp.AllAvailable = false
 			continue
@@ -269,42 +325,296 @@ func (s *store) VerifyStock(ctx context.Context, items []*pb.ItemWithQuantity) *
 				ID:       item.ID,
 				Quantity: item.Quantity,
 			})
+			resp.Shortages = append(resp.Shortages, &pb.StockShortage{
+				ItemID:    item.ID,
+				Requested: item.Quantity,
+				Available: max(available, 0),
+			})
 		}
 	}
 
 	return resp
 }
 
+// activeBookingsSQL sums the unexpired bookings per item. It is the set
+// counterpart of bookedQuantity.
+const activeBookingsSQL = `
+	SELECT item_id, SUM(quantity) AS booked
+	FROM booked_items
+	WHERE expires_at > CURRENT_TIMESTAMP
+	GROUP BY item_id
+`
+
+const availableSQL = `s.quantity - COALESCE(b.booked, 0)`
+
+const selectStockItemsSQL = `
+	SELECT s.id, s.quantity, s.name, s.price_id, s.description, s.img_path, s.created_at, s.updated_at,
+	       ` + availableSQL + ` AS available, s.price_amount, s.price_currency, s.station
+	FROM stock_items s
+	LEFT JOIN (` + activeBookingsSQL + `) b ON b.item_id = s.id
+`
+
+type querier interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// bookedQuantity returns how much of an item is held by unexpired bookings.
+func bookedQuantity(ctx context.Context, q querier, itemID string) (int32, error) {
+	var booked int32
+	err := q.QueryRowContext(ctx, `
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM booked_items
+		WHERE item_id = ?
+		  AND expires_at > CURRENT_TIMESTAMP
+	`, itemID).Scan(&booked)
+	return booked, err
+}
+
 func (s *store) GetStockItem(ctx context.Context, itemID string) (*pb.StockItem, error) {
-	var item pb.StockItem
+	item, err := scanStockItem(s.db.QueryRowContext(ctx, selectStockItemsSQL+`
+		WHERE s.id = ?
+	`, itemID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("stock item", itemID)
+		}
+		return nil, fmt.Errorf("failed to fetch stock item: %w", err)
+	}
+
+	return item, nil
+}
+
+// GetStockItems looks up several items at once, returned in the order of
+// itemIDs. It fails with NotFound if any of them does not exist.
+func (s *store) GetStockItems(ctx context.Context, itemIDs []string) ([]*pb.StockItem, error) {
+	if len(itemIDs) == 0 {
+		return []*pb.StockItem{}, nil
+	}
+
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(itemIDs)), ",")
+	args := make([]any, len(itemIDs))
+	for i, id := range itemIDs {
+		args[i] = id
+	}
 
-	err := s.db.QueryRowContext(ctx, `
-		SELECT id, quantity, name, price_id, description, img_path, created_at, updated_at
+	rows, err := s.db.QueryContext(ctx, selectStockItemsSQL+`
+		WHERE s.id IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch stock items: %w", err)
+	}
+	defer rows.Close()
+
+	byID := make(map[string]*pb.StockItem, len(itemIDs))
+	for rows.Next() {
+		item, err := scanStockItem(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan stock item: %w", err)
+		}
+		byID[item.ID] = item
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	items := make([]*pb.StockItem, 0, len(itemIDs))
+	for _, id := range itemIDs {
+		item, ok := byID[id]
+		if !ok {
+			return nil, common.NotFound("stock item", id)
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// ListStockItems returns a page of items ordered by q.OrderBy, with the ID as
+// tie-breaker, and the cursor of the next page if there is one.
+func (s *store) ListStockItems(ctx context.Context, q StockItemQuery) ([]*pb.StockItem, *stockCursor, error) {
+	var (
+		conds []string
+		args  []any
+	)
+
+	if q.NameQuery != "" {
+		conds = append(conds, `s.name LIKE ? ESCAPE '\'`)
+		args = append(args, "%"+escapeLike(q.NameQuery)+"%")
+	}
+	if q.InStockOnly {
+		conds = append(conds, availableSQL+` > 0`)
+	}
+
+	var sortCol string
+	switch q.OrderBy {
+	case pb.StockItemOrder_STOCK_ORDER_NAME:
+		sortCol = "s.name"
+	case pb.StockItemOrder_STOCK_ORDER_AVAILABLE:
+		sortCol = availableSQL
+	}
+
+	if c := q.After; c != nil {
+		switch q.OrderBy {
+		case pb.StockItemOrder_STOCK_ORDER_NAME:
+			conds = append(conds, `(s.name > ? OR (s.name = ? AND s.id > ?))`)
+			args = append(args, c.Name, c.Name, c.ID)
+		case pb.StockItemOrder_STOCK_ORDER_AVAILABLE:
+			conds = append(conds, `(`+availableSQL+` > ? OR (`+availableSQL+` = ? AND s.id > ?))`)
+			args = append(args, c.Available, c.Available, c.ID)
+		default:
+			conds = append(conds, `s.id > ?`)
+			args = append(args, c.ID)
+		}
+	}
+
+	query := selectStockItemsSQL
+	if len(conds) > 0 {
+		query += " WHERE " + strings.Join(conds, " AND ")
+	}
+	if sortCol != "" {
+		query += " ORDER BY " + sortCol + ", s.id"
+	} else {
+		query += " ORDER BY s.id"
+	}
+	// One extra row tells whether there is a next page.
+	query += " LIMIT ?"
+	args = append(args, q.PageSize+1)
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch stock items: %w", err)
+	}
+	defer rows.Close()
+
+	items := make([]*pb.StockItem, 0, q.PageSize)
+	for rows.Next() {
+		item, err := scanStockItem(rows)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to scan stock item: %w", err)
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	if len(items) <= q.PageSize {
+		return items, nil, nil
+	}
+
+	items = items[:q.PageSize]
+	last := items[len(items)-1]
+	next := &stockCursor{Order: q.OrderBy, ID: last.ID}
+	switch q.OrderBy {
+	case pb.StockItemOrder_STOCK_ORDER_NAME:
+		next.Name = last.Name
+	case pb.StockItemOrder_STOCK_ORDER_AVAILABLE:
+		next.Available = last.Available
+	}
+
+	return items, next, nil
+}
+
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
+
+// AdjustStockQuantity adds delta, which may be negative, to the quantity of
+// an item. Stock that is booked by pending orders cannot be taken away.
+func (s *store) AdjustStockQuantity(ctx context.Context, itemID string, delta int32) (*pb.StockItem, error) {
+	slog.InfoContext(ctx, "adjusting stock quantity", "item_id", itemID, "delta", delta)
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	var stockQty int32
+	err = tx.QueryRowContext(ctx, `
+		SELECT quantity
 		FROM stock_items
 		WHERE id = ?
-	`, itemID).Scan(
+	`, itemID).Scan(&stockQty)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, common.NotFound("stock item", itemID)
+		}
+		return nil, fmt.Errorf("failed to fetch stock item: %w", err)
+	}
+
+	bookedQty, err := bookedQuantity(ctx, tx, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch bookings: %w", err)
+	}
+
+	if stockQty+delta < bookedQty {
+		return nil, common.InsufficientStock(common.StockShortage{
+			ItemID:    itemID,
+			Requested: -delta,
+			Available: max(stockQty-bookedQty, 0),
+		})
+	}
+
+	_, err = tx.ExecContext(ctx, `
+		UPDATE stock_items
+		SET quantity = quantity + ?,
+		    updated_at = CURRENT_TIMESTAMP
+		WHERE id = ?
+	`, delta, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to adjust stock quantity: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return s.GetStockItem(ctx, itemID)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanStockItem(row rowScanner) (*pb.StockItem, error) {
+	var (
+		item          pb.StockItem
+		createdAt     time.Time
+		updatedAt     time.Time
+		priceAmount   int64
+		priceCurrency string
+	)
+
+	err := row.Scan(
 		&item.ID,
 		&item.Quantity,
 		&item.Name,
 		&item.PriceID,
 		&item.Description,
 		&item.ImgPath,
-		&item.CreatedAt,
-		&item.UpdatedAt,
+		&createdAt,
+		&updatedAt,
+		&item.Available,
+		&priceAmount,
+		&priceCurrency,
+		&item.Station,
 	)
-
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("item %s not found", itemID)
-		}
-		return nil, fmt.Errorf("failed to fetch stock item: %w", err)
+		return nil, err
 	}
 
+	if priceCurrency != "" {
+		item.Price = &pb.Money{Currency: priceCurrency, Amount: priceAmount}
+	}
+
+	item.CreatedAt = timestamppb.New(createdAt)
+	item.UpdatedAt = timestamppb.New(updatedAt)
 	return &item, nil
 }
 
 func (s *store) FinalizeBooking(ctx context.Context, orderID string) error {
-	log.Printf("Finalizing booking for order %s", orderID)
+	slog.DebugContext(ctx, "finalizing booking", "order_id", orderID)
 
 	tx, err := s.db.BeginTx(ctx, nil)
 	if err != nil {
@@ -312,6 +622,22 @@ func (s *store) FinalizeBooking(ctx context.Context, orderID string) error {
 	}
 	defer tx.Rollback()
 
+	// Finalizing is idempotent: an order whose stock was already deducted
+	// is reported as finalized again instead of failing on missing bookings.
+	var finalized int
+	err = tx.QueryRowContext(ctx, `
+		SELECT COUNT(*)
+		FROM finalized_bookings
+		WHERE order_id = ?
+	`, orderID).Scan(&finalized)
+	if err != nil {
+		return fmt.Errorf("failed to check finalized bookings: %w", err)
+	}
+	if finalized > 0 {
+		slog.InfoContext(ctx, "booking already finalized", "order_id", orderID)
+		return nil
+	}
+
 	rows, err := tx.QueryContext(ctx, `
 		SELECT item_id, SUM(quantity) AS total_qty
 		FROM booked_items
@@ -344,7 +670,7 @@ func (s *store) FinalizeBooking(ctx context.Context, orderID string) error {
 	}
 
 	if len(items) == 0 {
-		return fmt.Errorf("no active bookings found for order %s", orderID)
+		return common.Conflict("booking", orderID, fmt.Sprintf("no active bookings found for order %s", orderID))
 	}
 
 	for _, it := range items {
@@ -354,13 +680,19 @@ func (s *store) FinalizeBooking(ctx context.Context, orderID string) error {
 			FROM stock_items
 			WHERE id = ?
 		`, it.itemID).Scan(&stockQty)
+		if err == sql.ErrNoRows {
+			return common.NotFound("stock item", it.itemID)
+		}
 		if err != nil {
-			return fmt.Errorf("stock item %s not found: %w", it.itemID, err)
+			return fmt.Errorf("failed to fetch stock item %s: %w", it.itemID, err)
 		}
 
 		if stockQty < it.qty {
-			return fmt.Errorf("insufficient stock during finalize: item=%s have=%d need=%d",
-				it.itemID, stockQty, it.qty)
+			return common.InsufficientStock(common.StockShortage{
+				ItemID:    it.itemID,
+				Requested: it.qty,
+				Available: stockQty,
+			})
 		}
 
 		_, err = tx.ExecContext(ctx, `
@@ -382,11 +714,169 @@ func (s *store) FinalizeBooking(ctx context.Context, orderID string) error {
 		return fmt.Errorf("failed to delete bookings: %w", err)
 	}
 
+	_, err = tx.ExecContext(ctx, `
+		INSERT INTO finalized_bookings (order_id, finalized_at)
+		VALUES (?, CURRENT_TIMESTAMP)
+	`, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to record finalized booking: %w", err)
+	}
+
+	// The deducted quantities are kept so that ReturnFinalizedItems can put
+	// them back if the order is canceled later on.
+	for _, it := range items {
+		_, err = tx.ExecContext(ctx, `
+			INSERT INTO finalized_booking_items (order_id, item_id, quantity)
+			VALUES (?, ?, ?)
+		`, orderID, it.itemID, it.qty)
+		if err != nil {
+			return fmt.Errorf("failed to record finalized item %s: %w", it.itemID, err)
+		}
+	}
+
 	if err := tx.Commit(); err != nil {
 		return err
 	}
 
-	log.Printf("Booking finalized successfully for order %s", orderID)
+	slog.InfoContext(ctx, "finalized booking", "order_id", orderID)
+	return nil
+}
+
+// ReturnFinalizedItems adds the quantities deducted by FinalizeBooking back to
+// the stock. Each order's stock is returned at most once; returning it again,
+// or returning an order that was never finalized, returns nothing.
+func (s *store) ReturnFinalizedItems(ctx context.Context, orderID string) ([]*pb.ItemWithQuantity, error) {
+	if orderID == "" {
+		return nil, common.InvalidArgument("order ID", "is required")
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	res, err := tx.ExecContext(ctx, `
+		UPDATE finalized_bookings
+		SET returned_at = CURRENT_TIMESTAMP
+		WHERE order_id = ?
+		  AND returned_at IS NULL
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to mark booking as returned: %w", err)
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	if affected == 0 {
+		slog.InfoContext(ctx, "no finalized stock to return", "order_id", orderID)
+		return nil, nil
+	}
+
+	rows, err := tx.QueryContext(ctx, `
+		SELECT item_id, quantity
+		FROM finalized_booking_items
+		WHERE order_id = ?
+	`, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load finalized items: %w", err)
+	}
+	defer rows.Close()
+
+	var items []*pb.ItemWithQuantity
+	for rows.Next() {
+		var item pb.ItemWithQuantity
+		if err := rows.Scan(&item.ID, &item.Quantity); err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, item := range items {
+		_, err := tx.ExecContext(ctx, `
+			UPDATE stock_items
+			SET quantity = quantity + ?,
+			    updated_at = CURRENT_TIMESTAMP
+			WHERE id = ?
+		`, item.Quantity, item.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to return stock for %s: %w", item.ID, err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	slog.InfoContext(ctx, "returned finalized stock", "order_id", orderID, "items", len(items))
+	return items, nil
+}
+
+func (s *store) ListExpiredBookings(ctx context.Context) ([]ExpiredBooking, error) {
+	rows, err := s.db.QueryContext(ctx, `
+		SELECT booking_id, order_id, item_id, quantity, expires_at
+		FROM booked_items
+		WHERE expires_at <= CURRENT_TIMESTAMP
+		ORDER BY order_id, expires_at
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load expired bookings: %w", err)
+	}
+	defer rows.Close()
+
+	var expired []ExpiredBooking
+	for rows.Next() {
+		var (
+			bookingID string
+			orderID   string
+			item      pb.ItemWithQuantity
+			expiresAt time.Time
+		)
+		if err := rows.Scan(&bookingID, &orderID, &item.ID, &item.Quantity, &expiresAt); err != nil {
+			return nil, fmt.Errorf("failed to scan booking: %w", err)
+		}
+
+		if len(expired) == 0 || expired[len(expired)-1].OrderID != orderID {
+			expired = append(expired, ExpiredBooking{OrderID: orderID})
+		}
+		b := &expired[len(expired)-1]
+		b.BookingIDs = append(b.BookingIDs, bookingID)
+		b.Items = append(b.Items, &item)
+		if expiresAt.After(b.ExpiredAt) {
+			b.ExpiredAt = expiresAt
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return expired, nil
+}
+
+func (s *store) DeleteBookings(ctx context.Context, bookingIDs []string) error {
+	if len(bookingIDs) == 0 {
+		return nil
+	}
+
+	placeholders := make([]string, len(bookingIDs))
+	args := make([]any, len(bookingIDs))
+	for i, id := range bookingIDs {
+		placeholders[i] = "?"
+		args[i] = id
+	}
+
+	_, err := s.db.ExecContext(ctx, fmt.Sprintf(`
+		DELETE FROM booked_items
+		WHERE booking_id IN (%s)
+	`, strings.Join(placeholders, ",")), args...)
+	if err != nil {
+		return fmt.Errorf("failed to delete bookings: %w", err)
+	}
 	return nil
 }
 
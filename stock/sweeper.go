@@ -0,0 +1,37 @@
+package main
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Sweeper periodically purges expired bookings and tells the owning orders
+// about it. Events are published before the bookings are deleted, so an
+// order may hear about the same expiry twice but never miss it.
+type Sweeper struct {
+	service  StockService
+	interval time.Duration
+}
+
+func NewSweeper(service StockService, interval time.Duration) *Sweeper {
+	return &Sweeper{service: service, interval: interval}
+}
+
+func (s *Sweeper) Start(ctx context.Context) {
+	slog.Info("starting booking sweeper", "interval", s.interval)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.service.SweepExpiredBookings(ctx); err != nil {
+			slog.Error("failed to sweep expired bookings", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}